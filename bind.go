@@ -0,0 +1,335 @@
+package strfmt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//TypedTemplate is a Template bound to values of type T, its placeholders are checked against T by Bind
+//	Execute reads values through the accessors built by Bind, no field is looked up by name when rendering
+//	a TypedTemplate is immutable after Bind and safe for concurrent use
+type TypedTemplate[T any] struct {
+	tmpl *Template
+	//accessors of placeholders by the index of their nodes, nil for literals
+	accessors []*accessor
+}
+
+//Bind compiles a format string for values of type T, like Bind[Order]("Dear {Customer.Name}, {Total,10}")
+//	every placeholder should be a field, path or method of T, see FormatData for paths and tags,
+//	and its spec should be available for the type of the value, like a time layout for a time.Time field
+//	a placeholder which is not is ErrMissingKey, ErrAmbiguousKey or ErrBadSpec here instead of on every render
+//	paths through an interface{} could only be checked when rendering, they work like FormatData from there
+func Bind[T any](str string, opts ...Option) (*TypedTemplate[T], error) {
+	tmpl, err := Compile(str, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	accessors := make([]*accessor, len(tmpl.nodes))
+	for i, n := range tmpl.nodes {
+		ph, ok := n.(*Placeholder)
+		if !ok {
+			continue
+		}
+		acc, kind, err := tmpl.opts.bind_path(typ, ph.Path)
+		if err == nil {
+			kind, err = KindBadSpec, tmpl.opts.check_field(acc, ph)
+		}
+		if err != nil {
+			perr := new_parse_error(kind, str, ph.Start, ph.Raw)
+			perr.Err = err
+			return nil, perr
+		}
+		accessors[i] = acc
+	}
+	return &TypedTemplate[T]{tmpl: tmpl, accessors: accessors}, nil
+}
+
+//Template returns the Template the TypedTemplate is built on
+func (t *TypedTemplate[T]) Template() *Template {
+	return t.tmpl
+}
+
+//Execute renders the TypedTemplate with v
+//	nil pointers, missing map keys and indexes out of range are missing, see WithMissing
+func (t *TypedTemplate[T]) Execute(v T) (string, error) {
+	root := reflect.ValueOf(&v).Elem()
+	var result []byte
+	var err error
+	for i, n := range t.tmpl.nodes {
+		switch n := n.(type) {
+		case *Literal:
+			result = append(result, n.Value...)
+		case *Placeholder:
+			acc := t.accessors[i]
+			value, info, get_err := acc.get(&t.tmpl.opts, root, n.Path)
+			if get_err != nil {
+				result, err = t.tmpl.unresolved(result, n, get_err)
+			} else {
+				result, err = t.tmpl.render_field(result, n, value, info)
+			}
+			if err != nil {
+				return t.tmpl.str, err
+			}
+		}
+	}
+	return string(result), nil
+}
+
+//step kinds of an accessor
+const (
+	step_field = iota
+	step_method
+	step_key
+	step_index
+	//step_dynamic resolves the rest of the path when rendering, after an interface
+	step_dynamic
+)
+
+//step is one segment of a path bound to a type
+type step struct {
+	kind int
+	//field indexes from a struct, pointers between them are followed
+	field []int
+	//method index, of the pointer type if ptr is set
+	method int
+	ptr    bool
+	key    reflect.Value
+	index  int
+	//position of the step in the path
+	seg int
+}
+
+//accessor gets the value of a placeholder from a root value by the steps built by bind_path
+//	typ is the type of the value, nil if it is only known when rendering
+type accessor struct {
+	steps []step
+	info  *field_info
+	typ   reflect.Type
+}
+
+//bind_path builds an accessor of path from type typ, the ErrorKind tells why it fails
+func (o *options) bind_path(typ reflect.Type, path []Segment) (*accessor, ErrorKind, error) {
+	acc := &accessor{}
+	for i, seg := range path {
+		fail := func(format string, args ...interface{}) (*accessor, ErrorKind, error) {
+			return nil, KindMissingKey, &path_error{path: path_text(path[:i+1]), err: fmt.Errorf(format, args...)}
+		}
+		acc.info = nil
+
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() == reflect.Interface {
+			acc.steps = append(acc.steps, step{kind: step_dynamic, seg: i})
+			acc.typ = nil
+			return acc, 0, nil
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			if field, ok := o.flat_fields(typ)[seg.Name]; ok {
+				if len(field.ambiguous) > 0 {
+					err := &ambiguous_error{name: seg.Name, fields: append([]string{field.qualified}, field.ambiguous...)}
+					return nil, KindAmbiguousKey, &path_error{path: path_text(path[:i+1]), err: err}
+				}
+				acc.steps = append(acc.steps, step{kind: step_field, field: field.index, seg: i})
+				acc.info = field.info
+				typ = field_type(typ, field.index)
+				continue
+			}
+			if !acc.bind_method(&typ, seg.Name, i) {
+				return fail("no field or method %q in %s", seg.Name, typ)
+			}
+		case reflect.Map:
+			key, ok := map_key(typ.Key(), seg)
+			if !ok {
+				return fail("key %q could not be converted to %s", seg.Name, typ.Key())
+			}
+			acc.steps = append(acc.steps, step{kind: step_key, key: key, seg: i})
+			typ = typ.Elem()
+		case reflect.Slice, reflect.Array, reflect.String:
+			if seg.Index < 0 {
+				return fail("%s could only be indexed by number, not %q", typ, seg.Name)
+			}
+			if typ.Kind() == reflect.Array && seg.Index >= typ.Len() {
+				return fail("index %d out of range with length %d", seg.Index, typ.Len())
+			}
+			acc.steps = append(acc.steps, step{kind: step_index, index: seg.Index, seg: i})
+			if typ.Kind() == reflect.String {
+				typ = reflect.TypeOf(byte(0))
+			} else {
+				typ = typ.Elem()
+			}
+		default:
+			if !acc.bind_method(&typ, seg.Name, i) {
+				return fail("could not get %q from %s", seg.Name, typ)
+			}
+		}
+	}
+	acc.typ = typ
+	return acc, 0, nil
+}
+
+//bind_method adds a step of the method named name of *typ, and sets *typ to the type it returns
+func (acc *accessor) bind_method(typ *reflect.Type, name string, seg int) bool {
+	ptr := false
+	m, ok := (*typ).MethodByName(name)
+	if !ok {
+		ptr = true
+		m, ok = reflect.PtrTo(*typ).MethodByName(name)
+	}
+	if !ok || !is_getter(m.Type, 1) {
+		return false
+	}
+	acc.steps = append(acc.steps, step{kind: step_method, method: m.Index, ptr: ptr, seg: seg})
+	*typ = m.Type.Out(0)
+	return true
+}
+
+//field_type returns the type of the field at indexes from struct type typ, pointers between them are followed
+func field_type(typ reflect.Type, index []int) reflect.Type {
+	for i, field := range index {
+		if i > 0 {
+			for typ.Kind() == reflect.Ptr {
+				typ = typ.Elem()
+			}
+		}
+		typ = typ.Field(field).Type
+	}
+	return typ
+}
+
+//check_field checks the spec of ph, or the defaults in the tag of the field like fmt= or layout=, against the type of acc
+func (o *options) check_field(acc *accessor, ph *Placeholder) error {
+	spec := ph.Spec
+	if acc.info != nil {
+		if acc.info.err != nil {
+			return acc.info.err
+		}
+		if len(spec) == 0 && len(acc.info.format) > 0 {
+			return check_format(acc.typ, acc.info.format)
+		}
+		if len(spec) == 0 {
+			spec = acc.info.layout
+		}
+	}
+	return o.check_spec(acc.typ, spec)
+}
+
+//check_format reports whether the fmt= verbs of a tag could render values of type typ, by rendering the zero value of typ
+//	pointers are followed like rendering does, types only known when rendering are not checked
+func check_format(typ reflect.Type, format string) error {
+	if typ == nil {
+		return nil
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Interface {
+		return nil
+	}
+	if text := fmt.Sprintf(format, reflect.Zero(typ).Interface()); strings.Contains(text, "%!") {
+		return fmt.Errorf("fmt=%s is not available for %s: %s", format, typ, text)
+	}
+	return nil
+}
+
+//check_spec reports whether spec could render values of type typ, by rendering the zero value of typ
+//	registered specs, Formattable types, strings and types only known when rendering are not checked
+//	times are checked by check_time_spec, since rendering a time never fails
+func (o *options) check_spec(typ reflect.Type, spec string) error {
+	if len(spec) == 0 || typ == nil {
+		return nil
+	}
+	if _, ok := o.specs[spec_name(spec)]; ok {
+		return nil
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	//strings are parsed by their specs when rendering, as times or as numbers
+	if typ.Kind() == reflect.Interface || typ.Kind() == reflect.String || typ.Implements(formattable_type) || reflect.PtrTo(typ).Implements(formattable_type) {
+		return nil
+	}
+	if typ == time_type {
+		return check_time_spec(o, spec)
+	}
+	_, kind, err := o.format_value(reflect.Zero(typ).Interface(), spec)
+	if err != nil && kind == KindBadSpec {
+		return fmt.Errorf("spec %q is not available for %s: %w", spec, typ, err)
+	}
+	return nil
+}
+
+//check_time_spec reports whether spec is a time layout, any spec renders a time without an error,
+//	so a numeric or unit spec, or a text without any layout element like {Due:N2}, would be a wrong text silently
+func check_time_spec(o *options, spec string) error {
+	number_spec, _ := o.split_rounding(spec)
+	if _, ok := unit_specs[spec_name(number_spec)]; ok || is_standard_spec(number_spec) {
+		return fmt.Errorf("spec %q is a numeric spec, not a time layout for time.Time", spec)
+	}
+	if (time.Time{}).Format(spec) == spec {
+		return fmt.Errorf("spec %q has no layout element for time.Time, like 2006-01-02 or 15:04", spec)
+	}
+	return nil
+}
+
+//get walks the steps of acc from root, path is the path of the placeholder the steps are built from
+func (acc *accessor) get(o *options, root reflect.Value, path []Segment) (reflect.Value, *field_info, error) {
+	v := root
+	for _, s := range acc.steps {
+		fail := func(format string, args ...interface{}) (reflect.Value, *field_info, error) {
+			return reflect.Value{}, nil, &path_error{path: path_text(path[:s.seg+1]), err: fmt.Errorf(format, args...)}
+		}
+
+		var err error
+		if v, err = o.indirect(v); err != nil {
+			return reflect.Value{}, nil, &path_error{path: path_text(path[:s.seg+1]), err: err}
+		}
+		if !v.IsValid() {
+			return fail("nil value before %q", path[s.seg].Name)
+		}
+
+		switch s.kind {
+		case step_field:
+			for i, index := range s.field {
+				if i > 0 {
+					if v, err = o.indirect(v); err != nil || !v.IsValid() {
+						return fail("nil value before %q", path[s.seg].Name)
+					}
+				}
+				v = v.Field(index)
+			}
+		case step_method:
+			m := v
+			if !m.CanInterface() {
+				//a field which is not exported, read by WithUnexported(true)
+				m = reflect.ValueOf(interface_of(m))
+			}
+			if s.ptr {
+				m = pointer_to(m)
+			}
+			if v, err = call_method(m.Method(s.method)); err != nil {
+				return reflect.Value{}, nil, &path_error{path: path_text(path[:s.seg+1]), err: err}
+			}
+		case step_key:
+			value := v.MapIndex(s.key)
+			if !value.IsValid() {
+				return fail("no key %q in %s", path[s.seg].Name, v.Type())
+			}
+			v = value
+		case step_index:
+			if s.index >= v.Len() {
+				return fail("index %d out of range with length %d", s.index, v.Len())
+			}
+			v = v.Index(s.index)
+		case step_dynamic:
+			return o.resolve(v, path[s.seg:])
+		}
+	}
+	return v, acc.info, nil
+}
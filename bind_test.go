@@ -0,0 +1,134 @@
+package strfmt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type Invoice struct {
+	Id       int
+	Customer *Customer
+	Items    []string
+	Labels   map[string]string
+	Created  time.Time `strfmt:"created,layout=2006-01-02"`
+	Extra    interface{}
+}
+
+func (o Invoice) Count() int {
+	return len(o.Items)
+}
+
+func (o *Invoice) Title() (string, error) {
+	if o.Customer == nil {
+		return "", errors.New("no customer")
+	}
+	return "Invoice " + o.Customer.FirstName, nil
+}
+
+func Test_Bind(t *testing.T) {
+	tmpl, err := Bind[Invoice]("{Id,4} {Customer.first_name} {Items[1]} {Labels[\"env\"]} {created} {Count} {Title} {Extra.Name}")
+	if err != nil {
+		t.Error("Test_Bind throw error " + err.Error())
+		return
+	}
+	order := Invoice{
+		Id:       7,
+		Customer: &Customer{FirstName: "Mary"},
+		Items:    []string{"tea", "milk"},
+		Labels:   map[string]string{"env": "dev"},
+		Created:  time.Date(2021, 8, 2, 10, 4, 5, 0, time.UTC),
+		Extra:    &Person{Name: "Bob"},
+	}
+	res, err := tmpl.Execute(order)
+	if err != nil {
+		t.Error("Test_Bind throw error " + err.Error())
+	}
+	if res != "   7 Mary milk dev 2021-08-02 2 Invoice Mary Bob" {
+		t.Error("Test_Bind unexpected result " + res)
+	}
+
+	//values which are only known when rendering are missing
+	res, err = tmpl.Execute(Invoice{Items: []string{"tea"}})
+	if err != nil || res != "   0 {Customer.first_name} {Items[1]} {Labels[\"env\"]} 0001-01-01 1 {Title} {Extra.Name}" {
+		t.Error("Test_Bind unexpected result "+res, err)
+	}
+
+	ptr, err := Bind[*Invoice]("{Id}-{Title}", WithMissing(MissingError))
+	if err != nil {
+		t.Error("Test_Bind throw error " + err.Error())
+		return
+	}
+	res, err = ptr.Execute(&order)
+	if err != nil || res != "7-Invoice Mary" {
+		t.Error("Test_Bind [pointer] unexpected result "+res, err)
+	}
+	if _, err = ptr.Execute(&Invoice{}); !errors.Is(err, ErrMissingKey) || !strings.Contains(err.Error(), "no customer") {
+		t.Error("Test_Bind [pointer] should be ErrMissingKey ", err)
+	}
+}
+
+func Test_Bind_error(t *testing.T) {
+	var perr *ParseError
+	_, err := Bind[Invoice]("Dear {Customer.Nmae}")
+	if !errors.Is(err, ErrMissingKey) || !errors.As(err, &perr) || perr.Column != 6 {
+		t.Error("Test_Bind_error should be ErrMissingKey ", err)
+	}
+	if _, err = Bind[Invoice]("{Items.Name}"); !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_Bind_error should be ErrMissingKey ", err)
+	}
+	if _, err = Bind[Invoice]("{Id:2006-01-02}"); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_Bind_error should be ErrBadSpec ", err)
+	}
+	for _, str := range []string{"{created:N2}", "{created:F0@floor}", "{created:bytes}", "{created:total}"} {
+		if _, err = Bind[Invoice](str); !errors.Is(err, ErrBadSpec) {
+			t.Error("Test_Bind_error ["+str+"] should be ErrBadSpec ", err)
+		}
+	}
+	if _, err = Bind[Invoice]("{created:Jan 2, 2006} {created:15:04}"); err != nil {
+		t.Error("Test_Bind_error throw error " + err.Error())
+	}
+	type Tagged struct {
+		Price *float64 `strfmt:"price,fmt=%.2f"`
+		Name  string   `strfmt:"name,fmt=%d"`
+	}
+	if _, err = Bind[Tagged]("{price}"); err != nil {
+		t.Error("Test_Bind_error throw error " + err.Error())
+	}
+	if _, err = Bind[Tagged]("{name}"); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_Bind_error should be ErrBadSpec ", err)
+	}
+	if _, err = Bind[Tagged]("{name:x}"); err != nil {
+		t.Error("Test_Bind_error throw error " + err.Error())
+	}
+	if _, err = Bind[Pupil]("{Name}"); err != nil {
+		t.Error("Test_Bind_error throw error " + err.Error())
+	}
+	type Twins struct {
+		School School
+		Home   Person
+	}
+	if _, err = Bind[Twins]("{Name}"); !errors.Is(err, ErrAmbiguousKey) {
+		t.Error("Test_Bind_error should be ErrAmbiguousKey ", err)
+	}
+}
+
+//------------------------------------------//
+//        Benchmark Test Below           //
+//------------------------------------------//
+
+func Benchmark_TypedTemplate_Execute(b *testing.B) {
+	tmpl, err := Bind[*People](format_people)
+	if err != nil {
+		b.Error("Benchmark_TypedTemplate_Execute throw error " + err.Error())
+		return
+	}
+	for i := 0; i < b.N; i++ {
+		_, err = tmpl.Execute(g_people)
+		if err != nil {
+			b.Error("Benchmark_TypedTemplate_Execute throw error " + err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package strfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//decimal is a number as decimal digits, its value is 0.digits * 10^exp, like digits "125" and exp 1 for 1.25
+//	digits has no leading or trailing zeros, zero has no digits
+type decimal struct {
+	neg    bool
+	digits []byte
+	exp    int
+}
+
+//max_decimal_exp bounds the exponent of a decimal, so a text like 1e900000000 is an error instead of 900 MB of digits
+//	it is far more than the digits of any float64, which are 309 before the point and 324 after it at most
+const max_decimal_exp = 10000
+
+var (
+	errNotDecimal   = errors.New("not a decimal number")
+	errDecimalRange = fmt.Errorf("exponent of a decimal number exceeds %d", max_decimal_exp)
+)
+
+//decimal_of returns the decimal of a number arg, or of a decimal-like arg,
+//	like json.Number, *big.Int, *big.Float or *big.Rat, ok is false for other args
+//	the error is errNotDecimal for Inf, NaN and texts which are not numbers, errDecimalRange for exponents beyond max_decimal_exp
+func decimal_of(arg interface{}) (decimal, bool, error) {
+	var text string
+	switch v := arg.(type) {
+	case decimal:
+		return v, true, nil
+	case json.Number:
+		text = string(v)
+	case *big.Int:
+		text = v.Text(10)
+	case *big.Float:
+		text = v.Text('e', -1)
+	case *big.Rat:
+		//a rat like 1/3 has no exact decimal, 64 decimals are more than any picture shows
+		text = v.FloatString(64)
+	default:
+		n, ok := number_of(arg)
+		if !ok {
+			return decimal{}, false, nil
+		}
+		if d, finite := n.decimal(); finite {
+			return d, true, nil
+		}
+		return decimal{}, true, errNotDecimal
+	}
+	d, err := parse_decimal(text)
+	return d, true, err
+}
+
+//parse_decimal parses a number text like -12.5, 1e-3 or 1.25E+02
+//	the error is errNotDecimal for Inf, NaN and other texts, errDecimalRange for exponents beyond max_decimal_exp
+func parse_decimal(text string) (decimal, error) {
+	var d decimal
+	i := 0
+	if i < len(text) && (text[i] == '-' || text[i] == '+') {
+		d.neg = text[i] == '-'
+		i++
+	}
+
+	seen_digit, seen_point := false, false
+	for ; i < len(text); i++ {
+		ch := text[i]
+		if ch == '.' && !seen_point {
+			seen_point = true
+			continue
+		}
+		if ch < '0' || ch > '9' {
+			break
+		}
+		seen_digit = true
+		if ch == '0' && len(d.digits) == 0 {
+			//leading zeros only move the point
+			if seen_point {
+				d.exp--
+			}
+			continue
+		}
+		d.digits = append(d.digits, ch)
+		if !seen_point {
+			d.exp++
+		}
+	}
+	if !seen_digit {
+		return decimal{}, errNotDecimal
+	}
+
+	if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+		exp, err := strconv.Atoi(text[i+1:])
+		if err != nil {
+			return decimal{}, errNotDecimal
+		}
+		if exp > max_decimal_exp || exp < -max_decimal_exp {
+			return decimal{}, errDecimalRange
+		}
+		d.exp += exp
+		i = len(text)
+	}
+	if i != len(text) {
+		return decimal{}, errNotDecimal
+	}
+	d.trim()
+	//d.exp-1 is the power of 10 of the first digit
+	if d.exp-1 > max_decimal_exp || d.exp-1 < -max_decimal_exp {
+		return decimal{}, errDecimalRange
+	}
+	return d, nil
+}
+
+//trim removes trailing zeros of digits, zero is not negative
+func (d *decimal) trim() {
+	end := len(d.digits)
+	for end > 0 && d.digits[end-1] == '0' {
+		end--
+	}
+	d.digits = d.digits[:end]
+	if end == 0 {
+		d.neg = false
+		d.exp = 0
+	}
+}
+
+func (d decimal) is_zero() bool {
+	return len(d.digits) == 0
+}
+
+//shift multiplies d by 10^n
+func (d decimal) shift(n int) decimal {
+	if !d.is_zero() {
+		d.exp += n
+	}
+	return d
+}
+
+//RoundingMode is how numbers are rounded to the digits of a numeric spec
+//	numbers are rounded as decimal digits, so 2.675 is 2.68 by RoundHalfUp with 2 decimals,
+//	instead of 2.67 of the binary value 2.67499999999999982236431605997495353221893310546875
+type RoundingMode int
+
+const (
+	//RoundHalfUp rounds half away from zero, 2.5 is 3 and -2.5 is -3
+	RoundHalfUp RoundingMode = iota
+	//RoundHalfEven rounds half to the even digit, 2.5 is 2 and 3.5 is 4, like banks do
+	RoundHalfEven
+	//RoundFloor rounds toward negative infinity, 2.7 is 2 and -2.1 is -3
+	RoundFloor
+	//RoundCeiling rounds toward positive infinity, 2.1 is 3 and -2.7 is -2
+	RoundCeiling
+	//RoundTruncate rounds toward zero, 2.7 is 2 and -2.7 is -2
+	RoundTruncate
+)
+
+//rounding_names are the suffixes of numeric specs for rounding modes, like {0:F2@half-even}
+var rounding_names = map[string]RoundingMode{
+	"half-up":   RoundHalfUp,
+	"half-even": RoundHalfEven,
+	"floor":     RoundFloor,
+	"ceiling":   RoundCeiling,
+	"truncate":  RoundTruncate,
+}
+
+//WithRounding sets the RoundingMode of numeric specs, RoundHalfUp by default
+//	a placeholder could set its own by a suffix, like {0:F2@half-even}, {0:N0@floor} or {0:#,##0.00@truncate}
+func WithRounding(mode RoundingMode) Option {
+	return func(o *options) {
+		o.rounding = mode
+	}
+}
+
+//split_rounding splits a rounding suffix like @half-even from spec, the RoundingMode of options is used without it
+func (o *options) split_rounding(spec string) (string, RoundingMode) {
+	if i := strings.LastIndexByte(spec, '@'); i >= 0 {
+		if mode, ok := rounding_names[spec[i+1:]]; ok {
+			return spec[:i], mode
+		}
+	}
+	return spec, o.rounding
+}
+
+//round rounds d to decimals digits after the point by mode, a negative decimals rounds to tens, hundreds and so on
+func (d decimal) round(decimals int, mode RoundingMode) decimal {
+	keep := d.exp + decimals
+	if keep >= len(d.digits) {
+		return d
+	}
+
+	//first is the first digit dropped, rest tells whether any digit after it is not zero
+	first, rest := byte('0'), true
+	var digits []byte
+	if keep >= 0 {
+		first, rest = d.digits[keep], len(d.digits) > keep+1
+		digits = append(digits, d.digits[:keep]...)
+	} else {
+		d.exp = -decimals
+	}
+
+	up := false
+	switch mode {
+	case RoundHalfUp:
+		up = first >= '5'
+	case RoundHalfEven:
+		last := byte('0')
+		if len(digits) > 0 {
+			last = digits[len(digits)-1]
+		}
+		up = first > '5' || (first == '5' && (rest || (last-'0')%2 == 1))
+	case RoundFloor:
+		up = d.neg
+	case RoundCeiling:
+		up = !d.neg
+	}
+
+	if up {
+		//carry the 1 from the last kept digit
+		i := len(digits) - 1
+		for i >= 0 && digits[i] == '9' {
+			digits[i] = '0'
+			i--
+		}
+		if i >= 0 {
+			digits[i]++
+		} else {
+			digits = append([]byte{'1'}, digits...)
+			d.exp++
+		}
+	}
+	d.digits = digits
+	d.trim()
+	return d
+}
+
+//significant rounds d to n significant digits by mode
+func (d decimal) significant(n int, mode RoundingMode) decimal {
+	return d.round(n-d.exp, mode)
+}
+
+//fixed renders d with exactly decimals digits after the point, d should be rounded to them already
+func (d decimal) fixed(decimals int) string {
+	var b strings.Builder
+	if d.neg {
+		b.WriteByte('-')
+	}
+	integer := d.integer()
+	if integer == "" {
+		integer = "0"
+	}
+	b.WriteString(integer)
+	if decimals > 0 {
+		fraction := d.fraction()
+		b.WriteByte('.')
+		b.WriteString(fraction)
+		b.WriteString(strings.Repeat("0", decimals-len(fraction)))
+	}
+	return b.String()
+}
+
+//scientific renders d with prec decimals and an exponent of 3 digits at least, like 1.052033E+003
+//	d should be rounded to prec+1 significant digits already
+func (d decimal) scientific(prec int, letter byte) string {
+	var b strings.Builder
+	if d.neg {
+		b.WriteByte('-')
+	}
+	digits := string(d.digits)
+	exp := 0
+	if d.is_zero() {
+		digits = "0"
+	} else {
+		exp = d.exp - 1
+	}
+	digits += strings.Repeat("0", prec+1-len(digits))
+	b.WriteByte(digits[0])
+	if prec > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[1:])
+	}
+	b.WriteByte(letter)
+	if exp < 0 {
+		b.WriteByte('-')
+		exp = -exp
+	} else {
+		b.WriteByte('+')
+	}
+	text := strconv.Itoa(exp)
+	b.WriteString(strings.Repeat("0", 3-len(text)))
+	b.WriteString(text)
+	return b.String()
+}
+
+//integer returns the digits before the point, empty for numbers less than 1
+func (d decimal) integer() string {
+	if d.exp <= 0 {
+		return ""
+	}
+	if d.exp >= len(d.digits) {
+		return string(d.digits) + strings.Repeat("0", d.exp-len(d.digits))
+	}
+	return string(d.digits[:d.exp])
+}
+
+//fraction returns the digits after the point, without trailing zeros
+func (d decimal) fraction() string {
+	if d.exp >= len(d.digits) {
+		return ""
+	}
+	if d.exp < 0 {
+		return strings.Repeat("0", -d.exp) + string(d.digits)
+	}
+	return string(d.digits[d.exp:])
+}
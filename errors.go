@@ -0,0 +1,190 @@
+package strfmt
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//ErrorKind tells which kind of problem a ParseError or FormatError is
+type ErrorKind int
+
+const (
+	//KindUnclosedBrace means a placeholder is not closed by }
+	KindUnclosedBrace ErrorKind = iota + 1
+	//KindInvalidPlaceholder means a placeholder has an illegal width or spec
+	KindInvalidPlaceholder
+	//KindIndexOutOfRange means a placeholder index is not less than args length
+	KindIndexOutOfRange
+	//KindMissingKey means a placeholder key could not be found in args
+	KindMissingKey
+	//KindBadTimeLayout means a time spec could not be applied to its arg
+	KindBadTimeLayout
+	//KindUnescapedBrace means a single '{' or '}' which is not a part of placeholder, strict only
+	KindUnescapedBrace
+	//KindBadSpec means a spec could not be applied to the type of its arg
+	KindBadSpec
+	//KindNumberTooLarge means an index or width is larger than its Limits
+	KindNumberTooLarge
+	//KindAmbiguousKey means a key matches more than one nested field at the same depth, see WithCollisionError
+	KindAmbiguousKey
+)
+
+//sentinel errors for each ErrorKind, to be used with errors.Is
+var (
+	ErrUnclosedBrace      = errors.New("strfmt: unclosed brace")
+	ErrInvalidPlaceholder = errors.New("strfmt: invalid placeholder")
+	ErrIndexOutOfRange    = errors.New("strfmt: index out of range")
+	ErrMissingKey         = errors.New("strfmt: missing key")
+	ErrBadTimeLayout      = errors.New("strfmt: bad time layout")
+	ErrUnescapedBrace     = errors.New("strfmt: unescaped brace")
+	ErrBadSpec            = errors.New("strfmt: bad spec")
+	ErrNumberTooLarge     = errors.New("strfmt: number too large")
+	ErrAmbiguousKey       = errors.New("strfmt: ambiguous key")
+)
+
+var kind_sentinels = map[ErrorKind]error{
+	KindUnclosedBrace:      ErrUnclosedBrace,
+	KindInvalidPlaceholder: ErrInvalidPlaceholder,
+	KindIndexOutOfRange:    ErrIndexOutOfRange,
+	KindMissingKey:         ErrMissingKey,
+	KindBadTimeLayout:      ErrBadTimeLayout,
+	KindUnescapedBrace:     ErrUnescapedBrace,
+	KindBadSpec:            ErrBadSpec,
+	KindNumberTooLarge:     ErrNumberTooLarge,
+	KindAmbiguousKey:       ErrAmbiguousKey,
+}
+
+//String returns the description of the kind, like "unclosed brace"
+func (k ErrorKind) String() string {
+	if err, ok := kind_sentinels[k]; ok {
+		return strings.TrimPrefix(err.Error(), "strfmt: ")
+	}
+	return "ErrorKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+//Sentinel returns the sentinel error of the kind, like ErrUnclosedBrace
+func (k ErrorKind) Sentinel() error {
+	return kind_sentinels[k]
+}
+
+//ParseError is returned when a format string is malformed
+//	Offset is the byte offset in Str, Line and Column count from 1, Column counts runes
+//	Placeholder is the text of the broken placeholder from its '{'
+//	Err is the detail of the error if any, like which limit a number exceeds
+type ParseError struct {
+	Kind        ErrorKind
+	Str         string
+	Offset      int
+	Line        int
+	Column      int
+	Placeholder string
+	Err         error
+}
+
+//FormatError is returned when a well formed template could not be rendered with its args
+//	Err is the underlying error if any, like the error of time.Parse
+type FormatError struct {
+	Kind        ErrorKind
+	Str         string
+	Offset      int
+	Line        int
+	Column      int
+	Placeholder string
+	Err         error
+}
+
+func new_parse_error(kind ErrorKind, str string, offset int, placeholder string) *ParseError {
+	line, column := locate(str, offset)
+	return &ParseError{Kind: kind, Str: str, Offset: offset, Line: line, Column: column, Placeholder: placeholder}
+}
+
+func new_format_error(kind ErrorKind, str string, ph *Placeholder, err error) *FormatError {
+	line, column := locate(str, ph.Start)
+	return &FormatError{Kind: kind, Str: str, Offset: ph.Start, Line: line, Column: column, Placeholder: ph.Raw, Err: err}
+}
+
+func (e *ParseError) Error() string {
+	msg := "strfmt: " + e.Kind.String() + " at line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) +
+		": " + strconv.Quote(e.Placeholder)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+//Is reports whether target is the sentinel error of the kind
+func (e *ParseError) Is(target error) bool {
+	return target != nil && target == e.Kind.Sentinel()
+}
+
+//Unwrap returns the detail error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+//Snippet returns the line of the error with a caret under the error column
+func (e *ParseError) Snippet() string {
+	return snippet(e.Str, e.Offset)
+}
+
+func (e *FormatError) Error() string {
+	msg := "strfmt: " + e.Kind.String() + " at line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) +
+		": " + strconv.Quote(e.Placeholder)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+//Is reports whether target is the sentinel error of the kind
+func (e *FormatError) Is(target error) bool {
+	return target != nil && target == e.Kind.Sentinel()
+}
+
+//Unwrap returns the underlying error
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+//Snippet returns the line of the error with a caret under the error column
+func (e *FormatError) Snippet() string {
+	return snippet(e.Str, e.Offset)
+}
+
+//locate converts a byte offset of str to line and column, both count from 1
+func locate(str string, offset int) (int, int) {
+	if offset > len(str) {
+		offset = len(str)
+	}
+	line_start := strings.LastIndexByte(str[:offset], '\n') + 1
+	line := strings.Count(str[:line_start], "\n") + 1
+	column := utf8.RuneCountInString(str[line_start:offset]) + 1
+	return line, column
+}
+
+//snippet renders the line at offset with a caret below, tabs are kept to align the caret
+func snippet(str string, offset int) string {
+	if offset > len(str) {
+		offset = len(str)
+	}
+	line_start := strings.LastIndexByte(str[:offset], '\n') + 1
+	line_end := strings.IndexByte(str[offset:], '\n')
+	if line_end < 0 {
+		line_end = len(str)
+	} else {
+		line_end += offset
+	}
+
+	var caret []byte
+	for _, r := range str[line_start:offset] {
+		if r == '\t' {
+			caret = append(caret, '\t')
+		} else {
+			caret = append(caret, ' ')
+		}
+	}
+	caret = append(caret, '^')
+	return str[line_start:line_end] + "\n" + string(caret)
+}
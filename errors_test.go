@@ -0,0 +1,55 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_ParseError(t *testing.T) {
+	_, err := Compile("Today is\n\ta {0, Day")
+	if !errors.Is(err, ErrInvalidPlaceholder) {
+		t.Error("Test_ParseError should be ErrInvalidPlaceholder ", err)
+		t.FailNow()
+	}
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Error("Test_ParseError should be *ParseError ", err)
+		t.FailNow()
+	}
+	if perr.Line != 2 || perr.Column != 8 || perr.Offset != 16 || perr.Placeholder != "{0, D" {
+		t.Errorf("Test_ParseError unexpected position %+v", perr)
+	}
+	if perr.Snippet() != "\ta {0, Day\n\t      ^" {
+		t.Error("Test_ParseError unexpected snippet\n" + perr.Snippet())
+	}
+	fmt.Println(err)
+
+	_, err = Compile("Today is {day:2006")
+	if !errors.Is(err, ErrUnclosedBrace) {
+		t.Error("Test_ParseError should be ErrUnclosedBrace ", err)
+	}
+}
+
+func Test_FormatError(t *testing.T) {
+	_, err := Format(format_error_index_out_of_range, "wonderful")
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error("Test_FormatError should be ErrIndexOutOfRange ", err)
+	}
+
+	_, err = Format(format_time_normal, "wonderful")
+	if !errors.Is(err, ErrBadTimeLayout) {
+		t.Error("Test_FormatError should be ErrBadTimeLayout ", err)
+	}
+	var ferr *FormatError
+	var terr *time.ParseError
+	if !errors.As(err, &ferr) || !errors.As(err, &terr) {
+		t.Error("Test_FormatError should wrap *time.ParseError ", err)
+		t.FailNow()
+	}
+	if ferr.Placeholder != "{0:2006-01-02 15:04:05 Mon}" || ferr.Column != 17 {
+		t.Errorf("Test_FormatError unexpected position %+v", ferr)
+	}
+	fmt.Println(err)
+}
@@ -0,0 +1,332 @@
+package strfmt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+//field_info is how a struct field is seen by placeholders
+//	name is the Go field name, or the name in the strfmt tag (or json tag with WithJSONTags)
+type field_info struct {
+	name      string
+	index     int
+	omitempty bool
+	//embedded is an anonymous field without a tag name, whose fields are promoted like Go does
+	embedded bool
+	//unexported fields are skipped unless WithUnexported(true) is given,
+	//	exported fields of an unexported embedded struct are still promoted like Go does
+	unexported bool
+	//default rendering from tag options, used if the placeholder does not set its own
+	format string
+	layout string
+	align  *Placeholder
+	err    error
+}
+
+//WithJSONTags uses json tags for fields without a strfmt tag, so one struct drives both json and messages
+//	rules of strfmt tags for FormatData:
+//	- `strfmt:"first_name"` renames a field, placeholders use {first_name} instead of {FirstName}
+//	- `strfmt:"-"` skips a field
+//	- `strfmt:"name,omitempty"` renders a zero value as an empty string
+//	- `strfmt:"price,fmt=%.2f"` renders the field by fmt.Sprintf if the placeholder has no spec, a pointer by the value it points to
+//	- `strfmt:"created,layout=2006-01-02"` is the default time layout of the field, like {created:2006-01-02}
+//	- `strfmt:"code,width=-8"` is the default width of the field, like {code,-8}, any width like ^10 or -20.20! works
+//	a value with ',' could be quoted by single quotes, like `strfmt:"day,layout='Jan 2, 2006'"`
+//	a json tag is read by the same rules except omitempty, and a strfmt tag always wins over it
+func WithJSONTags(use bool) Option {
+	return func(o *options) {
+		o.jsonTags = use
+	}
+}
+
+//WithUnexported lets placeholders read fields which are not exported, they are skipped by default
+//	unexported fields are read through their address, FormatData copies a struct passed by value for it
+func WithUnexported(read bool) Option {
+	return func(o *options) {
+		o.unexported = read
+	}
+}
+
+//struct_fields returns the fields of struct type typ which could be used by placeholders, see WithJSONTags
+func (o *options) struct_fields(typ reflect.Type) []field_info {
+	fields := make([]field_info, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		info := field_info{name: sf.Name, index: i, embedded: sf.Anonymous, unexported: sf.PkgPath != ""}
+
+		tag, ok := sf.Tag.Lookup("strfmt")
+		from_json := false
+		if !ok && o.jsonTags {
+			tag, ok = sf.Tag.Lookup("json")
+			from_json = ok
+		}
+		if ok {
+			if tag == "-" {
+				continue
+			}
+			name, opts := parse_tag(tag)
+			if name != "" {
+				info.name = name
+				info.embedded = false
+			}
+			for _, opt := range opts {
+				key, value := opt, ""
+				if i := strings.IndexByte(opt, '='); i >= 0 {
+					key, value = opt[:i], opt[i+1:]
+				}
+				switch key {
+				case "omitempty":
+					//omitempty of json leaves a key out of json, it does not blank a value in messages
+					info.omitempty = !from_json
+				case "fmt":
+					info.format = value
+				case "layout":
+					info.layout = value
+				case "width":
+					info.align, info.err = parse_width(value)
+				}
+			}
+		}
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+//parse_tag splits a tag like "name,opt1,opt2" into name and options
+//	a ',' between single quotes does not split, the quotes of a value like layout='Jan 2, 2006' are removed
+func parse_tag(tag string) (string, []string) {
+	var parts []string
+	var part []byte
+	quoted := false
+	for i := 0; i < len(tag); i++ {
+		switch ch := tag[i]; {
+		case ch == '\'' && (quoted || (i > 0 && tag[i-1] == '=')):
+			quoted = !quoted
+		case ch == ',' && !quoted:
+			parts = append(parts, string(part))
+			part = nil
+		default:
+			part = append(part, ch)
+		}
+	}
+	parts = append(parts, string(part))
+	return parts[0], parts[1:]
+}
+
+//parse_width parses the width of a tag like -8, ^10 or -20.20! the same way as {key,width}
+func parse_width(width string) (*Placeholder, error) {
+	nodes, err := Parse("{0," + width + "}")
+	if err != nil {
+		return nil, fmt.Errorf("width=%s is not available: %w", width, err)
+	}
+	if len(nodes) != 1 {
+		return nil, fmt.Errorf("width=%s is not available", width)
+	}
+	ph, ok := nodes[0].(*Placeholder)
+	if !ok {
+		return nil, fmt.Errorf("width=%s is not available", width)
+	}
+	return ph, nil
+}
+
+//with_defaults returns a placeholder with the tag width of the field, if ph does not set its own width
+func (info *field_info) with_defaults(ph *Placeholder) *Placeholder {
+	if info.align == nil || ph.Width != 0 || ph.MaxWidth != 0 {
+		return ph
+	}
+	with := *ph
+	with.Width = info.align.Width
+	with.MaxWidth = info.align.MaxWidth
+	with.Ellipsis = info.align.Ellipsis
+	with.Align = info.align.Align
+	with.Fill = info.align.Fill
+	return &with
+}
+
+//FlattenMode decides which names of nested struct fields FormatData could use without a path
+//	fields of embedded (anonymous) structs are always promoted like Go does, {Name} for Student.People.Name
+//	a path like {School.Name} always works for named struct fields
+//	a field promoted through embedded structs only wins over one through named struct fields,
+//	then a shallower field wins, two fields of the same name left at the same depth are ambiguous,
+//	which are missing (or an error with WithCollisionError), instead of one of them picked silently
+type FlattenMode int
+
+const (
+	//FlattenNested promotes fields of named struct fields as well, {Name} for Student.School.Name
+	FlattenNested FlattenMode = iota
+	//FlattenEmbedded promotes fields of embedded structs only, {School.Name} is needed for named ones
+	FlattenEmbedded
+	//FlattenPrefix promotes fields of embedded structs, and names fields of named struct fields with prefix,
+	//like {School_Name} for Student.School.Name
+	FlattenPrefix
+)
+
+//WithFlatten sets the FlattenMode of FormatData, FlattenNested by default
+func WithFlatten(mode FlattenMode) Option {
+	return func(o *options) {
+		o.flatten = mode
+	}
+}
+
+//WithCollisionError returns ErrAmbiguousKey for ambiguous names of nested fields,
+//	whatever the MissingPolicy is
+func WithCollisionError(collision bool) Option {
+	return func(o *options) {
+		o.collisionError = collision
+	}
+}
+
+//flat_field is a field of a struct or its nested structs which could be got by name
+//	index is the field indexes from the struct, pointers between them are followed when it is got
+//	named is the number of named struct fields on the way, depth is the number of all struct fields
+//	ambiguous is the qualified names of other fields with the same name, named and depth
+type flat_field struct {
+	info      *field_info
+	index     []int
+	qualified string
+	named     int
+	depth     int
+	ambiguous []string
+}
+
+//flat_fields returns the fields of struct type typ by names, with the rules of FlattenMode
+//	tables are built once for a type and the options they depend on, and read only after that
+func (o *options) flat_fields(typ reflect.Type) map[string]*flat_field {
+	key := field_key{typ: typ, jsonTags: o.jsonTags, flatten: o.flatten, unexported: o.unexported, depth: o.limits.max_depth()}
+	if table, ok := field_cache.Load(key); ok {
+		return table.(map[string]*flat_field)
+	}
+	table := make(map[string]*flat_field)
+	o.flatten_type(table, typ, nil, "", "", 0, 0, map[reflect.Type]bool{typ: true})
+	cached, _ := field_cache.LoadOrStore(key, table)
+	return cached.(map[string]*flat_field)
+}
+
+//field_key is a struct type with the options which change its flat fields
+type field_key struct {
+	typ        reflect.Type
+	jsonTags   bool
+	flatten    FlattenMode
+	unexported bool
+	depth      int
+}
+
+//field_cache holds the flat fields of struct types by field_key, safe for concurrent use
+var field_cache sync.Map
+
+//flatten_type adds fields of typ to table, index and qualified are the path to typ,
+//	prefix is the name prefix of FlattenPrefix, seen are the struct types on the path to stop cycles
+func (o *options) flatten_type(table map[string]*flat_field, typ reflect.Type, index []int, qualified, prefix string, named, depth int, seen map[reflect.Type]bool) {
+	fields := o.struct_fields(typ)
+	for i := range fields {
+		info := &fields[i]
+		field_index := append(append([]int(nil), index...), info.index)
+		field_qualified := info.name
+		if qualified != "" {
+			field_qualified = qualified + "." + info.name
+		}
+		if info.unexported && !o.unexported {
+			continue
+		}
+		add_flat_field(table, prefix+info.name, &flat_field{info: info, index: field_index, qualified: field_qualified, named: named, depth: depth})
+	}
+
+	for i := range fields {
+		info := &fields[i]
+		field_type := typ.Field(info.index).Type
+		for field_type.Kind() == reflect.Ptr {
+			field_type = field_type.Elem()
+		}
+		if !is_flat_struct(field_type) || seen[field_type] || depth+1 >= o.limits.max_depth() {
+			continue
+		}
+		if info.unexported && !info.embedded && !o.unexported {
+			continue
+		}
+
+		field_index := append(append([]int(nil), index...), info.index)
+		field_qualified := info.name
+		if qualified != "" {
+			field_qualified = qualified + "." + info.name
+		}
+		field_prefix := prefix
+		field_named := named
+		switch {
+		case info.embedded:
+		case o.flatten == FlattenNested:
+			field_named++
+		case o.flatten == FlattenPrefix:
+			field_prefix = prefix + info.name + "_"
+		default:
+			continue
+		}
+
+		seen[field_type] = true
+		o.flatten_type(table, field_type, field_index, field_qualified, field_prefix, field_named, depth+1, seen)
+		delete(seen, field_type)
+	}
+}
+
+//add_flat_field adds field to table by name, the one with less named struct fields on the way wins,
+//	then the shallower one wins, or they are ambiguous
+func add_flat_field(table map[string]*flat_field, name string, field *flat_field) {
+	exists, ok := table[name]
+	switch {
+	case !ok || field.named < exists.named || (field.named == exists.named && field.depth < exists.depth):
+		table[name] = field
+	case field.named == exists.named && field.depth == exists.depth:
+		exists.ambiguous = append(exists.ambiguous, field.qualified)
+	}
+}
+
+//is_flat_struct reports whether the fields of typ could be promoted,
+//	types rendered as a whole like time.Time or a fmt.Stringer are not, see Formattable
+func is_flat_struct(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct || typ == time_type {
+		return false
+	}
+	return !has_format_method(typ) && !has_format_method(reflect.PtrTo(typ))
+}
+
+var (
+	time_type     = reflect.TypeOf(time.Time{})
+	stringer_type = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+//field_by_name returns the field named name of struct v, and its field_info
+//	see FlattenMode for the names of nested fields
+func (o *options) field_by_name(v reflect.Value, name string) (reflect.Value, *field_info, error) {
+	field, ok := o.flat_fields(v.Type())[name]
+	if !ok {
+		return reflect.Value{}, nil, fmt.Errorf("no field %q in %s", name, v.Type())
+	}
+	if len(field.ambiguous) > 0 {
+		return reflect.Value{}, nil, &ambiguous_error{name: name, fields: append([]string{field.qualified}, field.ambiguous...)}
+	}
+	for i, index := range field.index {
+		if i > 0 {
+			var err error
+			if v, err = o.indirect(v); err != nil {
+				return reflect.Value{}, nil, err
+			}
+			if !v.IsValid() {
+				return reflect.Value{}, nil, fmt.Errorf("nil value before %q of %s", name, field.qualified)
+			}
+		}
+		v = v.Field(index)
+	}
+	return v, field.info, nil
+}
+
+//ambiguous_error tells the fields of the same name at the same depth
+type ambiguous_error struct {
+	name   string
+	fields []string
+}
+
+func (e *ambiguous_error) Error() string {
+	return fmt.Sprintf("%q is ambiguous among %s", e.name, strings.Join(e.fields, ", "))
+}
@@ -0,0 +1,226 @@
+package strfmt
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Customer struct {
+	FirstName string `strfmt:"first_name"`
+	LastName  string `json:"last_name"`
+	Password  string `strfmt:"-" json:"password"`
+	Vip       bool   `strfmt:"vip,omitempty"`
+	Orders    int    `json:"orders,omitempty"`
+	Hidden    string `json:"-"`
+}
+
+func Test_FormatData_tags(t *testing.T) {
+	c := &Customer{FirstName: "Mary", LastName: "Smith", Password: "secret", Hidden: "hidden"}
+	res, err := FormatData("{first_name} {LastName} [{vip,5}] {Orders} {Hidden}", c)
+	if err != nil {
+		t.Error("Test_FormatData_tags throw error " + err.Error())
+	}
+	if res != "Mary Smith [     ] 0 hidden" {
+		t.Error("Test_FormatData_tags unexpected result " + res)
+	}
+
+	f := New(WithJSONTags(true), WithMissing(MissingError))
+	res, err = f.FormatData("{first_name} {last_name} [{orders}]", c)
+	if err != nil {
+		t.Error("Test_FormatData_tags throw error " + err.Error())
+	}
+	if res != "Mary Smith [0]" {
+		t.Error("Test_FormatData_tags unexpected result " + res)
+	}
+
+	for _, str := range []string{"{FirstName}", "{Password}", "{password}", "{Hidden}", "{LastName}"} {
+		_, err = f.FormatData(str, c)
+		if !errors.Is(err, ErrMissingKey) {
+			t.Error("Test_FormatData_tags ["+str+"] should be ErrMissingKey ", err)
+		}
+	}
+}
+
+type Product struct {
+	Code    string    `strfmt:"code,width=-8"`
+	Name    string    `strfmt:"name,width=.6!"`
+	Price   float64   `strfmt:"price,fmt=%.2f"`
+	Created time.Time `strfmt:"created,layout='Jan 2, 2006'"`
+	Stock   int       `strfmt:"stock,width=0>4"`
+	Bad     int       `strfmt:"bad,width=x"`
+}
+
+func Test_FormatData_tag_defaults(t *testing.T) {
+	p := &Product{Code: "A42", Name: "Green tea", Price: 1.99, Created: time.Date(2021, 8, 2, 10, 0, 0, 0, time.UTC), Stock: 7}
+	res, err := FormatData("[{code}][{name}][{price}][{created}][{stock}]", p)
+	if err != nil {
+		t.Error("Test_FormatData_tag_defaults throw error " + err.Error())
+	}
+	if res != "[A42     ][Green…][1.99][Aug 2, 2021][0007]" {
+		t.Error("Test_FormatData_tag_defaults unexpected result " + res)
+	}
+
+	//placeholders override the defaults of tags
+	res, err = FormatData("[{code,4}][{name,-10}][{price,6}][{created:2006-01-02}][{stock,2}]", p)
+	if err != nil {
+		t.Error("Test_FormatData_tag_defaults throw error " + err.Error())
+	}
+	if res != "[ A42][Green tea ][  1.99][2021-08-02][ 7]" {
+		t.Error("Test_FormatData_tag_defaults unexpected result " + res)
+	}
+
+	_, err = FormatData("{bad}", p)
+	if !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatData_tag_defaults should be ErrBadSpec ", err)
+	}
+
+	type Offer struct {
+		Price *float64 `strfmt:"price,fmt=%.2f"`
+	}
+	price := 1.5
+	res, err = FormatData("[{price}][{price,6}]", &Offer{Price: &price})
+	if err != nil || res != "[1.50][  1.50]" {
+		t.Error("Test_FormatData_tag_defaults [pointer] unexpected result "+res, err)
+	}
+	res, err = New(WithNil("n/a")).FormatData("[{price}]", Offer{})
+	if err != nil || res != "[n/a]" {
+		t.Error("Test_FormatData_tag_defaults [nil] unexpected result "+res, err)
+	}
+}
+
+type School struct {
+	Name string
+	City string
+}
+
+type Person struct {
+	Name string
+	City string
+}
+
+type Pupil struct {
+	Person
+	School School
+	Home   *Person
+	Grade  int
+}
+
+func Test_FormatData_flatten(t *testing.T) {
+	p := &Pupil{Person: Person{Name: "Mary", City: "Paris"}, School: School{Name: "Lycee", City: "Lyon"}, Grade: 3}
+	res, err := FormatData("{Name} {City} {School.Name} {School.City} {Person.Name} {Grade}", p)
+	if err != nil {
+		t.Error("Test_FormatData_flatten throw error " + err.Error())
+	}
+	if res != "Mary Paris Lycee Lyon Mary 3" {
+		t.Error("Test_FormatData_flatten unexpected result " + res)
+	}
+
+	type Twins struct {
+		School School
+		Home   Person
+	}
+	twins := &Twins{School: School{Name: "Lycee"}, Home: Person{Name: "Mary"}}
+	res, err = FormatData("{Name} {School.Name}", twins)
+	if err != nil || res != "{Name} Lycee" {
+		t.Error("Test_FormatData_flatten unexpected result "+res, err)
+	}
+	_, err = New(WithCollisionError(true)).FormatData("{Name}", twins)
+	if !errors.Is(err, ErrAmbiguousKey) || !strings.Contains(err.Error(), "School.Name, Home.Name") {
+		t.Error("Test_FormatData_flatten should be ErrAmbiguousKey ", err)
+	}
+
+	f := New(WithFlatten(FlattenPrefix), WithMissing(MissingError))
+	res, err = f.FormatData("{Name} {School_Name} {School_City} {Home_Name}", &Pupil{Person: Person{Name: "Mary"}, School: School{Name: "Lycee", City: "Lyon"}, Home: &Person{Name: "Home"}})
+	if err != nil || res != "Mary Lycee Lyon Home" {
+		t.Error("Test_FormatData_flatten [FlattenPrefix] unexpected result "+res, err)
+	}
+
+	f = New(WithFlatten(FlattenEmbedded), WithMissing(MissingError))
+	if _, err = f.FormatData("{Student}", &struct{ Info School }{}); !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_FormatData_flatten [FlattenEmbedded] should be ErrMissingKey ", err)
+	}
+	res, err = f.FormatData("{Name} {Info.Name}", &struct {
+		Person
+		Info School
+	}{Person{Name: "Mary"}, School{Name: "Lycee"}})
+	if err != nil || res != "Mary Lycee" {
+		t.Error("Test_FormatData_flatten [FlattenEmbedded] unexpected result "+res, err)
+	}
+
+	//nil pointers between nested fields are missing
+	_, err = New(WithMissing(MissingError)).FormatData("{Home.Name}", p)
+	if !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_FormatData_flatten should be ErrMissingKey ", err)
+	}
+}
+
+type Account struct {
+	Owner   *Person
+	Due     *time.Time
+	Extra   interface{}
+	created time.Time
+	secret  string
+}
+
+func Test_FormatData_reflection(t *testing.T) {
+	day := time.Date(2021, 8, 2, 10, 4, 5, 0, time.UTC)
+	account := Account{Extra: 42, created: day, secret: "s3"}
+
+	res, err := New(WithNil("n/a")).FormatData("{Owner} {Due:2006-01-02} {Extra} {created} {secret}", account)
+	if err != nil {
+		t.Error("Test_FormatData_reflection throw error " + err.Error())
+	}
+	if res != "n/a n/a 42 {created} {secret}" {
+		t.Error("Test_FormatData_reflection unexpected result " + res)
+	}
+
+	//unexported fields are read with WithUnexported, by value or by pointer
+	f := New(WithUnexported(true))
+	for _, arg := range []interface{}{account, &account} {
+		res, err = f.FormatData("{created:2006-01-02} {secret}", arg)
+		if err != nil || res != "2021-08-02 s3" {
+			t.Error("Test_FormatData_reflection [WithUnexported] unexpected result "+res, err)
+		}
+	}
+
+	//a pointer chain holding itself is an error instead of a hang
+	var loop interface{}
+	loop = &loop
+	_, err = New(WithMissing(MissingError)).FormatData("{Name}", loop)
+	if !errors.Is(err, ErrMissingKey) || !strings.Contains(err.Error(), "more than 32 pointers") {
+		t.Error("Test_FormatData_reflection should be ErrMissingKey ", err)
+	}
+}
+
+func Test_flat_fields_cache(t *testing.T) {
+	typ := reflect.TypeOf(Pupil{})
+	o, json_o := new_options(nil), new_options([]Option{WithJSONTags(true)})
+	if reflect.ValueOf(o.flat_fields(typ)).Pointer() != reflect.ValueOf(o.flat_fields(typ)).Pointer() {
+		t.Error("Test_flat_fields_cache fields should be built once for a type")
+	}
+	if reflect.ValueOf(o.flat_fields(typ)).Pointer() == reflect.ValueOf(json_o.flat_fields(typ)).Pointer() {
+		t.Error("Test_flat_fields_cache fields should be built again for other options")
+	}
+
+	tmpl, err := Compile("{Name} {School.City}")
+	if err != nil {
+		t.Error("Test_flat_fields_cache throw error " + err.Error())
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := tmpl.FormatData(&Pupil{Person: Person{Name: "Mary"}, School: School{City: "Lyon"}})
+			if err != nil || res != "Mary Lyon" {
+				t.Error("Test_flat_fields_cache unexpected result "+res, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,86 @@
+package strfmt
+
+//Formatter formats strings with its own options
+//	a Formatter is immutable after New and safe for concurrent use,
+//	so different parts of a program could use different conventions at the same time
+type Formatter struct {
+	opts options
+}
+
+//default_formatter is used by the package level functions
+var default_formatter = New()
+
+//New creates a Formatter with opts, like New(WithStrict(true), WithMissing(MissingEmpty))
+func New(opts ...Option) *Formatter {
+	return &Formatter{opts: new_options(opts)}
+}
+
+//Compile parses a format string into a Template with the options of the Formatter
+func (f *Formatter) Compile(str string) (*Template, error) {
+	nodes, err := f.parse(str)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{str: str, nodes: nodes, opts: f.opts}, nil
+}
+
+//Parse scans a format string into nodes with the options of the Formatter
+func (f *Formatter) Parse(str string) ([]Node, error) {
+	return f.parse(str)
+}
+
+//Format Strings with string args
+//	str:target string, args: strings
+//	string format should be like : some description{0}{1}
+func (f *Formatter) Format(str string, args ...string) (string, error) {
+	if len(str) == 0 {
+		return str, nil
+	}
+	tmpl, err := f.Compile(str)
+	if err != nil {
+		return str, err
+	}
+	return tmpl.Format(args...)
+}
+
+//Format Strings with typed args
+//	str:target string, args: any type, see Template.FormatAny for the conversion of types
+//	string format should be like : some description{0}{1:2006-01-02}
+func (f *Formatter) FormatAny(str string, args ...interface{}) (string, error) {
+	if len(str) == 0 {
+		return str, nil
+	}
+	tmpl, err := f.Compile(str)
+	if err != nil {
+		return str, err
+	}
+	return tmpl.FormatAny(args...)
+}
+
+//Format Strings with a map[string]string
+//	str:target string, args:map
+//	string format should be like : some description{field}
+func (f *Formatter) FormatMap(str string, args *map[string]string) (string, error) {
+	if len(str) == 0 {
+		return str, nil
+	}
+	tmpl, err := f.Compile(str)
+	if err != nil {
+		return str, err
+	}
+	return tmpl.FormatMap(args)
+}
+
+//Format Strings with struct type data
+//	str:target string, args:struct
+//	string format should be like : some description{field}
+func (f *Formatter) FormatData(str string, args interface{}) (string, error) {
+	if len(str) == 0 {
+		return str, nil
+	}
+	tmpl, err := f.Compile(str)
+	if err != nil {
+		return str, err
+	}
+	return tmpl.FormatData(args)
+}
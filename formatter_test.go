@@ -0,0 +1,90 @@
+package strfmt
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type Price struct {
+	Name  string
+	Price float64
+	Sold  time.Time
+}
+
+func Test_Formatter(t *testing.T) {
+	f := New(WithPadRune('.'), WithDelimiters('<', '>'), WithMissing(MissingEmpty))
+	res, err := f.Format("<0,-10>|<1,5>|{0}|<<<2>", "Mary", "43")
+	if err != nil {
+		t.Error("Test_Formatter throw error " + err.Error())
+	}
+	if res != "Mary......|...43|{0}|<" {
+		t.Error("Test_Formatter unexpected result " + res)
+	}
+
+	shanghai := time.FixedZone("CST", 8*3600)
+	f = New(WithTimeLayout(time.RFC3339), WithLocation(shanghai), WithFloatFormat('f', 1))
+	price := &Price{Name: "tea", Price: 1.25, Sold: time.Date(2021, 8, 2, 20, 0, 0, 0, time.UTC)}
+	res, err = f.FormatData("{Name} {Price} {Sold} {Sold:2006-01-02 15:04}", price)
+	if err != nil {
+		t.Error("Test_Formatter throw error " + err.Error())
+	}
+	if res != "tea 1.2 2021-08-03T04:00:00+08:00 2021-08-03 04:00" {
+		t.Error("Test_Formatter unexpected result " + res)
+	}
+	res, err = New(WithLocation(time.UTC)).FormatAny("{0} | {0:15:04}", time.Date(2021, 8, 2, 20, 0, 0, 0, time.FixedZone("CET", 3600)))
+	if err != nil || res != "Mon, 02 Aug 2021 19:00:00 +0000 | 19:00" {
+		t.Error("Test_Formatter [location] unexpected result "+res, err)
+	}
+
+	f = New(WithLimits(Limits{MaxIndex: 9, MaxWidth: 20}))
+	if _, err = f.Compile("{10}"); !errors.Is(err, ErrNumberTooLarge) {
+		t.Error("Test_Formatter [MaxIndex] should be ErrNumberTooLarge ", err)
+	}
+	if _, err = f.Compile("{0,21}"); !errors.Is(err, ErrNumberTooLarge) {
+		t.Error("Test_Formatter [MaxWidth] should be ErrNumberTooLarge ", err)
+	}
+}
+
+func Test_Formatter_WithSpec(t *testing.T) {
+	f := New(WithSpec("upper", func(arg interface{}, spec string) (string, error) {
+		return strings.ToUpper(arg.(string)), nil
+	}))
+	res, err := f.Format("Today is a {0,-12:upper}{1:upper}", "wonderful", "day")
+	if err != nil {
+		t.Error("Test_Formatter_WithSpec throw error " + err.Error())
+	}
+	if res != "Today is a WONDERFUL   DAY" {
+		t.Error("Test_Formatter_WithSpec unexpected result " + res)
+	}
+
+	//registered specs belong to their Formatter only
+	_, err = Format("{0:upper}", "wonderful")
+	if !errors.Is(err, ErrBadTimeLayout) {
+		t.Error("Test_Formatter_WithSpec should be ErrBadTimeLayout ", err)
+	}
+}
+
+func Test_Formatter_concurrent(t *testing.T) {
+	left := New(WithPadRune('-'))
+	right := New(WithPadRune('+'))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l, _ := left.Format("{0,4}", "a")
+				r, _ := right.Format("{0,4}", "a")
+				d, _ := Format("{0,4}", "a")
+				if l != "---a" || r != "+++a" || d != "   a" {
+					t.Error("Test_Formatter_concurrent unexpected result " + l + r + d)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,3 +1,3 @@
 module github.com/taloric/strfmt
 
-go 1.17
+go 1.18
@@ -0,0 +1,27 @@
+package strfmt
+
+import "reflect"
+
+//FormatMapOf formats a string with a map of any value type, like map[string]int or map[string]interface{}
+//	str:target string, m:map, a nil map has no keys
+//	values are converted like FormatAny, so specs work the same way, like {due:2006-01-02} for a time.Time
+//	string format should be like : some description{field}, or a path into a value like {user.Name}
+func FormatMapOf[V any](str string, m map[string]V) (string, error) {
+	if len(str) == 0 {
+		return str, nil
+	}
+	tmpl, err := default_formatter.Compile(str)
+	if err != nil {
+		return str, err
+	}
+	return ExecuteMap(tmpl, m)
+}
+
+//ExecuteMap renders a Template with a map of any value type, see FormatMapOf
+//	keys not found in m are rendered by the MissingPolicy of WithMissing
+func ExecuteMap[V any](t *Template, m map[string]V) (string, error) {
+	return t.format_keys(func(key string) (interface{}, bool) {
+		arg, ok := m[key]
+		return arg, ok
+	}, reflect.ValueOf(m))
+}
@@ -0,0 +1,65 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func Test_FormatMapOf(t *testing.T) {
+	res, err := FormatMapOf("{a,3}+{b}={c}", map[string]int{"a": 1, "b": 2, "c": 3})
+	if err != nil || res != "  1+2=3" {
+		t.Error("Test_FormatMapOf unexpected result "+res, err)
+	}
+
+	day := time.Date(2021, 8, 2, 10, 4, 5, 0, time.UTC)
+	res, err = FormatMapOf("{name} {day:2006-01-02} {user.Name} {ip} {none}", map[string]interface{}{
+		"name": "Mary",
+		"day":  day,
+		"user": &Person{Name: "Bob"},
+		"ip":   net.IPv4(10, 0, 0, 1),
+		"none": nil,
+	})
+	if err != nil || res != "Mary 2021-08-02 Bob 10.0.0.1 <nil>" {
+		t.Error("Test_FormatMapOf unexpected result "+res, err)
+	}
+
+	res, err = FormatMapOf("{ip}", map[string]fmt.Stringer{"ip": net.IPv4(127, 0, 0, 1)})
+	if err != nil || res != "127.0.0.1" {
+		t.Error("Test_FormatMapOf unexpected result "+res, err)
+	}
+
+	tmpl, err := Compile("{a} {b}", WithMissing(MissingError))
+	if err != nil {
+		t.Error("Test_FormatMapOf throw error " + err.Error())
+		return
+	}
+	var empty map[string]float64
+	if _, err = ExecuteMap(tmpl, empty); !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_FormatMapOf should be ErrMissingKey ", err)
+	}
+}
+
+func Test_FormatMap_nil(t *testing.T) {
+	res, err := FormatMap(format_date, nil)
+	if err != nil || res != format_date {
+		t.Error("Test_FormatMap_nil unexpected result "+res, err)
+	}
+	res, err = New().FormatMap("{a}", nil)
+	if err != nil || res != "{a}" {
+		t.Error("Test_FormatMap_nil unexpected result "+res, err)
+	}
+}
+
+func Test_FormatData_map(t *testing.T) {
+	res, err := FormatData("{name} is {age} in {city.Name}", map[string]interface{}{
+		"name": "Mary",
+		"age":  43,
+		"city": struct{ Name string }{"Lyon"},
+	})
+	if err != nil || res != "Mary is 43 in Lyon" {
+		t.Error("Test_FormatData_map unexpected result "+res, err)
+	}
+}
@@ -0,0 +1,89 @@
+package strfmt
+
+type missing_kind int
+
+const (
+	missing_default missing_kind = iota
+	missing_keep
+	missing_empty
+	missing_error
+	missing_callback
+)
+
+//MissingPolicy decides what a placeholder renders when its key or index is not found in args
+//	the same policy applies to positional {0} and named {key} placeholders,
+//	the key passed to Marker and Callback is the text in {}, like "0" or "key"
+type MissingPolicy struct {
+	kind missing_kind
+	fn   func(key string) (string, error)
+}
+
+var (
+	//MissingDefault keeps the behaviour of Format and FormatMap:
+	//an index out of range is an error, a missing key is kept as "{key}" (or an error if strict)
+	MissingDefault = MissingPolicy{}
+	//MissingKeep keeps the placeholder text, like "{key}" or "{0,10}", in the output
+	MissingKeep = MissingPolicy{kind: missing_keep}
+	//MissingEmpty renders an empty string, padded to the placeholder width
+	MissingEmpty = MissingPolicy{kind: missing_empty}
+	//MissingError returns ErrIndexOutOfRange for indexes and ErrMissingKey for keys
+	MissingError = MissingPolicy{kind: missing_error}
+)
+
+//MissingMarker renders a visible marker returned by fn, padded to the placeholder width
+//	if fn is nil the marker is like <missing:key>
+func MissingMarker(fn func(key string) string) MissingPolicy {
+	if fn == nil {
+		fn = func(key string) string {
+			return "<missing:" + key + ">"
+		}
+	}
+	return MissingPolicy{kind: missing_callback, fn: func(key string) (string, error) {
+		return fn(key), nil
+	}}
+}
+
+//MissingCallback calls fn for each missing key, fn may return a value or an error to stop rendering
+//	errors returned by fn are wrapped in *FormatError with KindMissingKey or KindIndexOutOfRange
+//	if fn is nil the policy is MissingError
+func MissingCallback(fn func(key string) (string, error)) MissingPolicy {
+	if fn == nil {
+		return MissingError
+	}
+	return MissingPolicy{kind: missing_callback, fn: fn}
+}
+
+//WithMissing sets the MissingPolicy of rendering, MissingDefault if not set
+func WithMissing(policy MissingPolicy) Option {
+	return func(o *options) {
+		o.missing = policy
+	}
+}
+
+//missing renders placeholder ph whose arg is not found
+//	kind is KindIndexOutOfRange for positional placeholders and KindMissingKey for named ones
+//	cause tells why it is not found if any, like which segment of a path could not be resolved
+func (t *Template) missing(result []byte, ph *Placeholder, kind ErrorKind, cause error) ([]byte, error) {
+	policy := t.opts.missing
+	if policy.kind == missing_default {
+		if kind == KindIndexOutOfRange || t.opts.strict {
+			policy = MissingError
+		} else {
+			policy = MissingKeep
+		}
+	}
+
+	switch policy.kind {
+	case missing_keep:
+		return append(result, ph.Raw...), nil
+	case missing_empty:
+		return t.pad(result, ph, ""), nil
+	case missing_callback:
+		value, err := policy.fn(ph.Key)
+		if err != nil {
+			return result, new_format_error(kind, t.str, ph, err)
+		}
+		return t.pad(result, ph, value), nil
+	}
+	return result, new_format_error(kind, t.str, ph, cause)
+}
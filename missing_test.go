@@ -0,0 +1,61 @@
+package strfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_WithMissing(t *testing.T) {
+	str := "[{0}]-[{1,-4}]-[{name}]-[{day,4}]"
+	args := map[string]string{"0": "zero", "day": "Mon"}
+	cases := []struct {
+		policy MissingPolicy
+		format string
+		data   string
+	}{
+		{MissingKeep, "[zero]-[{1,-4}]-[{name}]-[{day,4}]", "[zero]-[{1,-4}]-[{name}]-[ Mon]"},
+		{MissingEmpty, "[zero]-[    ]-[]-[    ]", "[zero]-[    ]-[]-[ Mon]"},
+		{MissingMarker(nil), "[zero]-[<missing:1>]-[<missing:name>]-[<missing:day>]", "[zero]-[<missing:1>]-[<missing:name>]-[ Mon]"},
+		{MissingCallback(func(key string) (string, error) { return "?", nil }), "[zero]-[?   ]-[?]-[   ?]", "[zero]-[?   ]-[?]-[ Mon]"},
+	}
+	for _, c := range cases {
+		tmpl, err := Compile(str, WithMissing(c.policy))
+		if err != nil {
+			t.Error("Test_WithMissing throw error " + err.Error())
+			t.FailNow()
+		}
+		res, err := tmpl.Format("zero")
+		if err != nil || res != c.format {
+			t.Error("Test_WithMissing Format got "+res, err)
+		}
+		res, err = tmpl.FormatMap(&args)
+		if err != nil || res != c.data {
+			t.Error("Test_WithMissing FormatMap got "+res, err)
+		}
+	}
+
+	tmpl, _ := Compile(str, WithMissing(MissingError))
+	_, err := tmpl.FormatMap(&args)
+	if !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_WithMissing should be ErrMissingKey ", err)
+	}
+	_, err = tmpl.Format("zero")
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error("Test_WithMissing should be ErrIndexOutOfRange ", err)
+	}
+
+	stop := errors.New("stop")
+	tmpl, _ = Compile(str, WithMissing(MissingCallback(func(key string) (string, error) { return "", stop })))
+	_, err = tmpl.FormatMap(&args)
+	if !errors.Is(err, stop) || !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_WithMissing should wrap callback error ", err)
+	}
+
+	tmpl, _ = Compile(str, WithMissing(MissingCallback(nil)))
+	if _, err = tmpl.FormatMap(&args); !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_WithMissing [nil callback] should be ErrMissingKey ", err)
+	}
+	if _, err = tmpl.Format("zero"); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error("Test_WithMissing [nil callback] should be ErrIndexOutOfRange ", err)
+	}
+}
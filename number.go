@@ -0,0 +1,298 @@
+package strfmt
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//number is a numeric arg, one of i, u or f is used by kind
+//	kind is reflect.Int64, reflect.Uint64 or reflect.Float64, bits is the bit size of the original type
+type number struct {
+	kind reflect.Kind
+	i    int64
+	u    uint64
+	f    float64
+	bits int
+}
+
+//number_of returns the number in arg, ok is false if arg is not an integer or a float
+//	named types like type Level int16 are numbers as well
+func number_of(arg interface{}) (number, bool) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: reflect.Int64, i: v.Int(), bits: v.Type().Bits()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: reflect.Uint64, u: v.Uint(), bits: v.Type().Bits()}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: reflect.Float64, f: v.Float(), bits: v.Type().Bits()}, true
+	}
+	return number{}, false
+}
+
+//is_integer reports whether n is an int or an uint
+func (n number) is_integer() bool {
+	return n.kind != reflect.Float64
+}
+
+//digits returns the sign and the decimal digits of the absolute value of an integer n
+func (n number) digits() (bool, string) {
+	if n.kind == reflect.Uint64 {
+		return false, strconv.FormatUint(n.u, 10)
+	}
+	if n.i < 0 {
+		//-(i+1)+1 keeps the smallest int64 from overflowing
+		return true, strconv.FormatUint(uint64(-(n.i+1))+1, 10)
+	}
+	return false, strconv.FormatInt(n.i, 10)
+}
+
+//bits_of returns an integer n as an uint of its bit size, negative ints in two's complement like .NET does
+func (n number) bits_of() uint64 {
+	if n.kind == reflect.Uint64 {
+		return n.u
+	}
+	if n.bits >= 64 {
+		return uint64(n.i)
+	}
+	return uint64(n.i) & (1<<uint(n.bits) - 1)
+}
+
+//big_float returns n as an exact big.Float, for integers which could not be exact in a float64
+func (n number) big_float() *big.Float {
+	switch n.kind {
+	case reflect.Int64:
+		return new(big.Float).SetInt64(n.i)
+	case reflect.Uint64:
+		return new(big.Float).SetUint64(n.u)
+	}
+	return big.NewFloat(n.f)
+}
+
+//format_number renders a number arg with a standard numeric spec, like .NET does
+//	N2 grouped with 2 decimals, F3 fixed with 3 decimals, E4 scientific with 4 decimals, D6 integer with 6 digits at least,
+//	X8 or x upper or lower hex, B binary, P1 percent with 1 decimal, G general like strconv 'g',
+//	R the shortest text which reads back the same value, whatever WithFloatFormat is
+//	S3 with 3 significant digits, like 0.00123 of 0.0012345 or 12300 of 12345
+//	the digits after the letter are the precision, N, F and P have 2 decimals without them, E and S have 6
+//	N, F, P, E and S round decimal digits by the RoundingMode of WithRounding, or of a suffix like F2@half-even
+//	other specs are custom numeric specs like #,##0.00, see format_picture
+//	both work for decimal-like args as well, like json.Number or *big.Float, see decimal_of,
+//	G, R, D, X and B render them as the closest int64, uint64 or float64
+//	ok is false if arg is not a number
+func (o *options) format_number(arg interface{}, spec string) (string, bool, error) {
+	spec, mode := o.split_rounding(spec)
+	if !is_standard_spec(spec) {
+		d, ok, err := decimal_of(arg)
+		if !ok {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", true, err
+		}
+		value, err := format_picture(d, spec, mode)
+		return value, true, err
+	}
+
+	n, ok := number_of(arg)
+	var d decimal
+	finite := true
+	if ok {
+		d, finite = n.decimal()
+	} else {
+		//decimal-like args, like json.Number or the strings of Format
+		var err error
+		if d, ok, err = decimal_of(arg); !ok {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", true, err
+		}
+		n = d.number()
+	}
+
+	letter := spec[0]
+	prec := -1
+	if len(spec) > 1 {
+		prec = parse_number(spec[1:])
+		if max := o.limits.max_width(); prec < 0 || prec > max {
+			return "", true, fmt.Errorf("precision of %q exceeds MaxWidth %d", spec, max)
+		}
+	}
+	with_default := func(default_prec int) int {
+		if prec < 0 {
+			return default_prec
+		}
+		return prec
+	}
+
+	//decimal specs round decimal digits by mode, Inf and NaN are rendered as they are
+	if !finite && strings.IndexByte("NnFfPpEeSs", letter) >= 0 {
+		return strconv.FormatFloat(n.f, 'g', -1, n.bits), true, nil
+	}
+
+	switch letter {
+	case 'N', 'n':
+		prec = with_default(2)
+		return group_digits(d.round(prec, mode).fixed(prec)), true, nil
+	case 'F', 'f':
+		prec = with_default(2)
+		return d.round(prec, mode).fixed(prec), true, nil
+	case 'P', 'p':
+		prec = with_default(2)
+		return group_digits(d.shift(2).round(prec, mode).fixed(prec)) + "%", true, nil
+	case 'E', 'e':
+		prec = with_default(6)
+		return d.significant(prec+1, mode).scientific(prec, letter), true, nil
+	case 'S', 's':
+		prec = with_default(6)
+		if prec < 1 {
+			prec = 1
+		}
+		r := d.significant(prec, mode)
+		decimals := prec - r.exp
+		if r.is_zero() {
+			decimals = prec - 1
+		}
+		if decimals < 0 {
+			decimals = 0
+		}
+		return r.fixed(decimals), true, nil
+	case 'G', 'g':
+		return n.general(prec, letter), true, nil
+	case 'R', 'r':
+		return n.general(-1, 'g'), true, nil
+	case 'D', 'd', 'X', 'x', 'B', 'b':
+		if !n.is_integer() {
+			return "", true, fmt.Errorf("spec %q is only available for integers", spec)
+		}
+		return n.radix(letter, with_default(0)), true, nil
+	}
+	return "", true, fmt.Errorf("%q is not a standard numeric spec", spec)
+}
+
+//is_standard_spec reports whether spec is a standard numeric spec, a letter with optional precision digits
+func is_standard_spec(spec string) bool {
+	return len(spec) > 0 && strings.IndexByte("NnFfPpEeSsGgRrDdXxBb", spec[0]) >= 0 && (len(spec) == 1 || is_number(spec[1:]))
+}
+
+//decimal returns n as a decimal, ok is false for Inf and NaN
+//	floats are the shortest decimals which read back the same float
+func (n number) decimal() (decimal, bool) {
+	if !n.is_integer() {
+		d, err := parse_decimal(strconv.FormatFloat(n.f, 'e', -1, n.bits))
+		return d, err == nil
+	}
+	neg, digits := n.digits()
+	d, _ := parse_decimal(digits)
+	d.neg = neg && !d.is_zero()
+	return d, true
+}
+
+//number returns the number closest to d, for specs which work on numbers like G, R, D, X and B
+//	integers within int64 or uint64 are exact, others are the closest float64
+func (d decimal) number() number {
+	if len(d.fraction()) == 0 {
+		text := d.fixed(0)
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return number{kind: reflect.Int64, i: i, bits: 64}
+		}
+		if u, err := strconv.ParseUint(text, 10, 64); err == nil {
+			return number{kind: reflect.Uint64, u: u, bits: 64}
+		}
+	}
+	f, _ := strconv.ParseFloat(d.fixed(len(d.fraction())), 64)
+	return number{kind: reflect.Float64, f: f, bits: 64}
+}
+
+//format_text renders a string arg with a standard or custom numeric spec, like the args of Format
+//	ok is false if spec is not a numeric spec, so the string could be parsed as a time instead
+func (o *options) format_text(text string, spec string) (string, bool, error) {
+	number_spec, _ := o.split_rounding(spec)
+	if !is_standard_spec(number_spec) && !is_picture(number_spec) {
+		return "", false, nil
+	}
+	d, err := parse_decimal(text)
+	if err != nil {
+		return "", true, fmt.Errorf("spec %q needs a number, not %q: %w", spec, text, err)
+	}
+	value, _, err := o.format_number(d, spec)
+	return value, true, err
+}
+
+//general renders n in the shorter of fixed and scientific like strconv 'g', with prec significant digits
+//	a negative or zero prec is the shortest text which reads back the same value, like G0 of .NET
+func (n number) general(prec int, letter byte) string {
+	if prec == 0 {
+		prec = -1
+	}
+	var text string
+	if n.is_integer() {
+		if prec < 0 {
+			neg, digits := n.digits()
+			if neg {
+				return "-" + digits
+			}
+			return digits
+		}
+		text = n.big_float().Text('g', prec)
+	} else {
+		text = strconv.FormatFloat(n.f, 'g', prec, n.bits)
+	}
+	if letter == 'G' {
+		text = strings.Replace(text, "e", "E", 1)
+	}
+	return text
+}
+
+//radix renders an integer n in decimal, hex or binary by letter, with prec digits at least
+func (n number) radix(letter byte, prec int) string {
+	var neg bool
+	var digits string
+	switch letter {
+	case 'D', 'd':
+		neg, digits = n.digits()
+	case 'X':
+		digits = strings.ToUpper(strconv.FormatUint(n.bits_of(), 16))
+	case 'x':
+		digits = strconv.FormatUint(n.bits_of(), 16)
+	default:
+		digits = strconv.FormatUint(n.bits_of(), 2)
+	}
+	if len(digits) < prec {
+		digits = strings.Repeat("0", prec-len(digits)) + digits
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+//group_digits puts ',' between every 3 digits of the integer part of a number text like -1234567.89
+func group_digits(text string) string {
+	start := 0
+	if len(text) > 0 && (text[0] == '-' || text[0] == '+') {
+		start = 1
+	}
+	end := start
+	for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		end++
+	}
+	if end-start <= 3 {
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString(text[:start])
+	for i := start; i < end; i++ {
+		if i > start && (end-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte(text[i])
+	}
+	b.WriteString(text[end:])
+	return b.String()
+}
@@ -0,0 +1,153 @@
+package strfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func Test_format_number(t *testing.T) {
+	cases := []struct {
+		spec   string
+		arg    interface{}
+		result string
+	}{
+		{"N2", 1234567.891, "1,234,567.89"},
+		{"N", -1234, "-1,234.00"},
+		{"N0", uint64(math.MaxUint64), "18,446,744,073,709,551,615"},
+		{"F3", float32(0.1), "0.100"},
+		{"F", 42, "42.00"},
+		{"f0", 2.5, "3"},
+		{"E4", 1052.0329112756, "1.0520E+003"},
+		{"e", -0.00012, "-1.200000e-004"},
+		{"E2", int64(math.MaxInt64), "9.22E+018"},
+		{"D6", 1234, "001234"},
+		{"D6", -1234, "-001234"},
+		{"d", int64(math.MinInt64), "-9223372036854775808"},
+		{"X8", 255, "000000FF"},
+		{"x", int8(-1), "ff"},
+		{"X", int16(-2), "FFFE"},
+		{"B", uint8(5), "101"},
+		{"b8", 5, "00000101"},
+		{"P1", 0.12345, "12.3%"},
+		{"P", 12, "1,200.00%"},
+		{"G", 1234.5, "1234.5"},
+		{"G3", 1234.5, "1.23E+03"},
+		{"g", float32(0.1), "0.1"},
+		{"G", int64(-42), "-42"},
+		{"G2", 123456, "1.2E+05"},
+		{"N2", Level(1234), "1,234.00"},
+		{"N2", json.Number("12345.675"), "12,345.68"},
+		{"E3", new(big.Float).SetInt64(1052), "1.052E+003"},
+		{"D6", big.NewInt(-42), "-000042"},
+		{"X", json.Number("255"), "FF"},
+		{"G", json.Number("0.1"), "0.1"},
+	}
+	o := new_options(nil)
+	for _, c := range cases {
+		res, kind, err := o.format_value(c.arg, c.spec)
+		if err != nil {
+			t.Errorf("Test_format_number [%s] throw error %s, kind %s", c.spec, err.Error(), kind)
+			continue
+		}
+		if res != c.result {
+			t.Errorf("Test_format_number [%s] unexpected result %s", c.spec, res)
+		}
+	}
+
+	for _, spec := range []string{"D2", "X", "Q", "N2x"} {
+		if _, _, err := o.format_value(1.5, spec); err == nil {
+			t.Errorf("Test_format_number [%s] should throw error", spec)
+		}
+	}
+}
+
+func Test_FormatAny_number(t *testing.T) {
+	res, err := FormatAny("Total: {0,12:N2} ({1:P0}) #{2:D5} 0x{3:X4}", 1234.5, 0.25, 42, 48879)
+	if err != nil || res != "Total:     1,234.50 (25%) #00042 0xBEEF" {
+		t.Error("Test_FormatAny_number unexpected result "+res, err)
+	}
+	if _, err = FormatAny("{0:D2}", 1.5); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatAny_number should be ErrBadSpec ", err)
+	}
+
+	//string args of Format are parsed as numbers by numeric specs, and as times by others
+	res, err = Format("{0:N2} {1:#,##0.00;(#,##0.00)} {2:P1} {3:D5} {4:X} {5:F2@half-even} {6:2006-01-02}",
+		"1234.5", "-42", "0.1234", "42", "255", "2.665", "Mon, 02 Aug 2021 10:04:05 +0000")
+	if err != nil || res != "1,234.50 (42.00) 12.3% 00042 FF 2.66 2021-08-02" {
+		t.Error("Test_FormatAny_number [Format] unexpected result "+res, err)
+	}
+	for _, str := range []string{"{0:N2}", "{0:0.00}", "{0:D}"} {
+		if _, err = Format(str, "abc"); !errors.Is(err, ErrBadSpec) {
+			t.Error("Test_FormatAny_number ["+str+"] should be ErrBadSpec ", err)
+		}
+	}
+	if _, err = Format("{0:N2}", "1e9000000000000000"); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatAny_number should be ErrBadSpec ", err)
+	}
+	if _, err = Format("{0:D}", "1.5"); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatAny_number should be ErrBadSpec ", err)
+	}
+}
+
+func Test_format_number_rounding(t *testing.T) {
+	cases := []struct {
+		spec   string
+		arg    interface{}
+		result string
+	}{
+		{"F0@half-up", -2.5, "-3"},
+		{"F0@half-even", 2.5, "2"},
+		{"F0@half-even", 3.5, "4"},
+		{"F0@half-even", -2.5, "-2"},
+		{"F2@half-even", 2.675, "2.68"},
+		{"F2@half-even", 2.665, "2.66"},
+		{"F2@half-even", 2.6651, "2.67"},
+		{"F0@floor", 2.7, "2"},
+		{"F0@floor", -2.1, "-3"},
+		{"F0@ceiling", 2.1, "3"},
+		{"F0@ceiling", -2.7, "-2"},
+		{"F0@truncate", -2.7, "-2"},
+		{"F2", 1.005, "1.01"},
+		{"F1", 0.05, "0.1"},
+		{"F1@floor", -0.04, "-0.1"},
+		{"F1@ceiling", -0.04, "0.0"},
+		{"N0@half-even", 1234567.5, "1,234,568"},
+		{"P1@truncate", 0.12399, "12.3%"},
+		{"E2@truncate", 1299, "1.29E+003"},
+		{"E2", 9999, "1.00E+004"},
+		{"E1", 0, "0.0E+000"},
+		{"#,##0.00@half-even", 0.125, "0.12"},
+		{"0;(0)@floor", -1.5, "(2)"},
+		{"S3", 0.0012345, "0.00123"},
+		{"S3", 12345, "12300"},
+		{"S3", 1.5, "1.50"},
+		{"S2", 9.96, "10"},
+		{"S2", -0.000999, "-0.0010"},
+		{"s2@floor", 0.0129, "0.012"},
+		{"S3", 0, "0.00"},
+		{"S", math.Pi, "3.14159"},
+		{"S3", math.Inf(1), "+Inf"},
+	}
+	o := new_options(nil)
+	for _, c := range cases {
+		res, kind, err := o.format_value(c.arg, c.spec)
+		if err != nil {
+			t.Errorf("Test_format_number_rounding [%s] throw error %s, kind %s", c.spec, err.Error(), kind)
+			continue
+		}
+		if res != c.result {
+			t.Errorf("Test_format_number_rounding [%s] %v unexpected result %s", c.spec, c.arg, res)
+		}
+	}
+
+	res, err := New(WithRounding(RoundHalfEven)).FormatAny("{0:F0} {0:F0@half-up} {1:0.0}", 2.5, 0.25)
+	if err != nil || res != "2 3 0.2" {
+		t.Error("Test_format_number_rounding unexpected result "+res, err)
+	}
+	if _, err = FormatAny("{0:F2@nearest}", 1.5); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_format_number_rounding should be ErrBadSpec ", err)
+	}
+}
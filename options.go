@@ -0,0 +1,198 @@
+package strfmt
+
+import (
+	"strings"
+	"time"
+)
+
+//options holds the settings of parsing and rendering
+type options struct {
+	strict  bool
+	missing MissingPolicy
+	//layout to parse string args with a time spec, and to render times without spec
+	timeLayout string
+	location   *time.Location
+	floatFmt   byte
+	floatPrec  int
+	rounding   RoundingMode
+	padRune    rune
+	widthMode  WidthMode
+	ellipsis   string
+	jsonTags   bool
+	flatten    FlattenMode
+	//unexported lets placeholders read fields which are not exported
+	unexported bool
+	//nilText is rendered for nil values, like a nil pointer field
+	nilText string
+	//collisionError returns ambiguous names as errors instead of missing ones
+	collisionError bool
+	open           byte
+	close          byte
+	limits         Limits
+	specs          map[string]SpecFunc
+}
+
+//Option changes the behaviour of New, Compile and Parse
+type Option func(*options)
+
+//Limits bounds the numbers in placeholders, numbers larger than the limits are ErrNumberTooLarge
+//	MaxIndex is the largest index like {300}, MaxWidth is the largest width like {0,300}
+//	MaxDepth is the most pointers, interfaces and nested values followed from one value,
+//	which stops cycles like a struct pointing to itself
+//	zero means DefaultMaxIndex, DefaultMaxWidth or DefaultMaxDepth, a negative value means no limit other than 2^31-1
+type Limits struct {
+	MaxIndex int
+	MaxWidth int
+	MaxDepth int
+}
+
+//default limits of placeholder numbers, they keep a template like {0,2000000000} from allocating gigabytes
+const (
+	DefaultMaxIndex = 65535
+	DefaultMaxWidth = 65535
+	DefaultMaxDepth = 32
+)
+
+func (l Limits) max_index() int {
+	return limit(l.MaxIndex, DefaultMaxIndex)
+}
+
+func (l Limits) max_width() int {
+	return limit(l.MaxWidth, DefaultMaxWidth)
+}
+
+func (l Limits) max_depth() int {
+	return limit(l.MaxDepth, DefaultMaxDepth)
+}
+
+func limit(value, default_value int) int {
+	if value == 0 {
+		return default_value
+	}
+	if value < 0 || value > max_number {
+		return max_number
+	}
+	return value
+}
+
+//SpecFunc renders arg with the spec after ':' of a placeholder, see WithSpec
+type SpecFunc func(arg interface{}, spec string) (string, error)
+
+//WithStrict turns malformed text into errors instead of passing it through
+//	lenient (default) policy:
+//	- '{' not followed by a key, like "{ Day" or "{}", is kept as it is
+//	- a single '}' without a partner is kept as it is
+//	- text like "{0 Day}" which starts like a placeholder but is not one is kept as it is
+//	- keys not found in args are kept as "{key}" in the output
+//	- named placeholders are kept as they are by Format, which only knows indexes
+//	strict policy returns ErrUnescapedBrace, ErrInvalidPlaceholder or ErrMissingKey for each of them,
+//	the last two are decided by WithMissing instead if a MissingPolicy is given
+//	placeholders which are not closed, bad widths and out of range indexes are errors in both policies
+func WithStrict(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+//WithTimeLayout sets the layout times are exchanged with, time.RFC1123Z by default
+//	string args with a time spec like {0:2006-01-02} are parsed with it,
+//	and time fields of FormatData without spec are rendered with it
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeLayout = layout
+	}
+}
+
+//WithLocation converts times to loc before they are rendered, with a time spec or with the time layout of WithTimeLayout
+func WithLocation(loc *time.Location) Option {
+	return func(o *options) {
+		o.location = loc
+	}
+}
+
+//WithFloatFormat sets format and prec of strconv.FormatFloat for floats without a spec
+//	default is 'g' with -1, the shortest text which reads back the same float, like 1234.5 or 1e+21
+//	'f' with 2 is fixed like 1234.50, 'e' with 6 is scientific like 1.234500e+03, any format of strconv works
+//	floats are rendered with their own bit size, so a float32 0.1 is 0.1 instead of 0.10000000149011612
+//	a placeholder could set its own precision by a numeric spec, like {0:F2}, {0:E3}, {0:G5} or {0:R}
+//	it panics if format is not a format of strconv.FormatFloat
+func WithFloatFormat(format byte, prec int) Option {
+	if strings.IndexByte("beEfgGxX", format) < 0 {
+		panic("strfmt: float format " + string(format) + " is not available")
+	}
+	return func(o *options) {
+		o.floatFmt = format
+		o.floatPrec = prec
+	}
+}
+
+//WithPadRune sets the rune to fill the width of placeholders, space by default
+func WithPadRune(r rune) Option {
+	return func(o *options) {
+		o.padRune = r
+	}
+}
+
+//WithDelimiters replaces '{' and '}' of placeholders, like WithDelimiters('<', '>') for <0,-10>
+//	doubled delimiters escape themselves like {{ and }}
+//	it panics if open and close are the same, or could be a part of a key
+func WithDelimiters(open, close byte) Option {
+	if open == close || is_key_char(open) || is_key_char(close) {
+		panic("strfmt: delimiters " + string(open) + string(close) + " are not available")
+	}
+	return func(o *options) {
+		o.open = open
+		o.close = close
+	}
+}
+
+//WithLimits bounds the index and width numbers in placeholders
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+//WithSpec registers fn to render placeholders whose spec is named name
+//	the name of a spec is its leading letters, '-' and '_', like "upper" of {0:upper} or "bytes" of {0:bytes2}
+//	registered specs are looked up before any builtin spec, fn gets the whole spec
+func WithSpec(name string, fn SpecFunc) Option {
+	return func(o *options) {
+		specs := make(map[string]SpecFunc, len(o.specs)+1)
+		for k, v := range o.specs {
+			specs[k] = v
+		}
+		specs[name] = fn
+		o.specs = specs
+	}
+}
+
+func new_options(opts []Option) options {
+	o := options{
+		timeLayout: time.RFC1123Z,
+		floatFmt:   'g',
+		floatPrec:  -1,
+		padRune:    ' ',
+		ellipsis:   "…",
+		nilText:    "<nil>",
+		open:       '{',
+		close:      '}',
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+//spec_name returns the leading letters, '-' and '_' of spec
+func spec_name(spec string) string {
+	i := 0
+	for i < len(spec) {
+		ch := spec[i]
+		if (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') && ch != '-' && ch != '_' {
+			break
+		}
+		i++
+	}
+	return spec[:i]
+}
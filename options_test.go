@@ -0,0 +1,65 @@
+package strfmt
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func Test_WithStrict(t *testing.T) {
+	cases := map[string]error{
+		format_error_only_left_brace:   ErrUnescapedBrace,
+		format_error_without_condition: ErrUnescapedBrace,
+		format_error_only_right_brace:  ErrUnescapedBrace,
+		"Today is }":                   ErrUnescapedBrace,
+		"Today is {0 x} Day":           ErrInvalidPlaceholder,
+		"Today is {0, Day":             ErrInvalidPlaceholder,
+	}
+	for str, expect := range cases {
+		_, err := Compile(str, WithStrict(true))
+		if !errors.Is(err, expect) {
+			t.Error("Test_WithStrict ["+str+"] should be ", expect, " got ", err)
+		}
+		_, err = Compile(str, WithStrict(false))
+		if err != nil && expect == ErrUnescapedBrace {
+			t.Error("Test_WithStrict ["+str+"] should not throw error ", err)
+		}
+	}
+
+	tmpl, err := Compile(format_today_info, WithStrict(true))
+	if err != nil {
+		t.Error("Test_WithStrict throw error " + err.Error())
+		t.FailNow()
+	}
+	args := map[string]string{"DayofWeek": "Monday"}
+	_, err = tmpl.FormatMap(&args)
+	if !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_WithStrict should be ErrMissingKey ", err)
+	}
+	_, err = tmpl.Format("Monday")
+	if !errors.Is(err, ErrMissingKey) {
+		t.Error("Test_WithStrict should be ErrMissingKey ", err)
+	}
+}
+
+func Test_no_panic(t *testing.T) {
+	alphabet := "{}{}0a ,-:x\n"
+	args := map[string]string{"0": "zero", "a": "a"}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		str := make([]byte, r.Intn(12))
+		for j := range str {
+			str[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		for _, strict := range []bool{false, true} {
+			tmpl, err := Compile(string(str), WithStrict(strict))
+			if err != nil {
+				continue
+			}
+			tmpl.Format("zero")
+			tmpl.FormatMap(&args)
+		}
+		Format(string(str), "zero")
+		FormatMap(string(str), &args)
+	}
+}
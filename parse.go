@@ -0,0 +1,378 @@
+package strfmt
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+//Node is one element of a parsed format string, either *Literal or *Placeholder
+type Node interface {
+	//Offset returns the byte offset of the node in the format string
+	Offset() int
+	//Text returns the source text of the node in the format string
+	Text() string
+}
+
+//Literal is a plain text between placeholders, with {{ and }} already unescaped
+type Literal struct {
+	Value string
+	Start int
+	Raw   string
+}
+
+//Alignment is the side a placeholder value is put on when padding to Width
+type Alignment int
+
+const (
+	//AlignRight pads spaces on left, like {0,10} or {0,>10}
+	AlignRight Alignment = iota
+	//AlignLeft pads spaces on right, like {0,-10} or {0,<10}
+	AlignLeft
+	//AlignCenter pads spaces on both sides, the extra one on right, like {0,^10}
+	AlignCenter
+)
+
+//Placeholder is a substitution like {key,width:spec}
+//	Key is the whole key text, Path is Key split into segments, like User, Address and City of {User.Address.City}
+//	Index is the argument index if Key starts with a number, otherwise -1
+//	Fill is the rune to pad with, like '0' of {0,0>6}, zero means the pad rune of options
+//	MaxWidth truncates longer values, like 20 of {0,-10.20}, zero means no limit
+//	Ellipsis is set by '!' after MaxWidth, like {0,.20!}, truncated values end with the ellipsis of options
+//	Spec is the text after ':' with {{ and }} already unescaped
+type Placeholder struct {
+	Key      string
+	Path     []Segment
+	Index    int
+	Width    int
+	MaxWidth int
+	Ellipsis bool
+	Align    Alignment
+	Fill     rune
+	Spec     string
+	Start    int
+	Raw      string
+}
+
+func (l *Literal) Offset() int  { return l.Start }
+func (l *Literal) Text() string { return l.Raw }
+
+func (p *Placeholder) Offset() int  { return p.Start }
+func (p *Placeholder) Text() string { return p.Raw }
+
+//Parse scans a format string into literal and placeholder nodes
+//	this is the only scanner of the package, Format/FormatMap/FormatData and Template are all built on it
+//	text like { Day or a single } which is not a placeholder is kept in literals as it is,
+//	unless WithStrict(true) is given, see WithStrict for the whole policy
+func Parse(str string, opts ...Option) ([]Node, error) {
+	return New(opts...).parse(str)
+}
+
+func (f *Formatter) parse(str string) ([]Node, error) {
+	p := parser{str: str, opts: &f.opts}
+	return p.parse()
+}
+
+type parser struct {
+	str     string
+	opts    *options
+	pos     int
+	nodes   []Node
+	literal []byte
+	//start offset of current literal
+	start int
+	//start offset of current placeholder
+	ph_start int
+}
+
+//flush ends current literal at offset end
+func (p *parser) flush(end int) {
+	if len(p.literal) > 0 {
+		p.nodes = append(p.nodes, &Literal{Value: string(p.literal), Start: p.start, Raw: p.str[p.start:end]})
+		p.literal = nil
+	}
+	p.start = end
+}
+
+//error returns a ParseError at pos for current placeholder
+func (p *parser) error(kind ErrorKind) error {
+	return p.error_detail(kind, nil)
+}
+
+//error_detail returns a ParseError at pos for current placeholder, which wraps err
+func (p *parser) error_detail(kind ErrorKind, err error) error {
+	end := p.pos + 1
+	if end > len(p.str) {
+		end = len(p.str)
+	}
+	perr := new_parse_error(kind, p.str, p.pos, p.str[p.ph_start:end])
+	perr.Err = err
+	return perr
+}
+
+//skip_space moves pos to the next non space char, and fails if it reaches the end
+func (p *parser) skip_space() error {
+	for p.pos < len(p.str) && p.str[p.pos] == ' ' {
+		p.pos++
+	}
+	if p.pos == len(p.str) {
+		return p.error(KindUnclosedBrace)
+	}
+	return nil
+}
+
+func (p *parser) parse() ([]Node, error) {
+	str := p.str
+	length := len(str)
+	open, close := p.opts.open, p.opts.close
+
+	for p.pos < length {
+		ch := str[p.pos]
+
+		if ch == close {
+			//escape char for }}, a single } is kept as it is
+			if p.pos+1 < length && str[p.pos+1] == close {
+				p.pos++
+			} else if p.opts.strict {
+				p.ph_start = p.pos
+				return nil, p.error(KindUnescapedBrace)
+			}
+			p.literal = append(p.literal, ch)
+			p.pos++
+			continue
+		}
+
+		if ch != open {
+			p.literal = append(p.literal, ch)
+			p.pos++
+			continue
+		}
+
+		//escape char for {{
+		if p.pos+1 < length && str[p.pos+1] == open {
+			p.literal = append(p.literal, ch)
+			p.pos += 2
+			continue
+		}
+
+		ph, err := p.placeholder()
+		if err != nil {
+			return nil, err
+		}
+		if ph == nil {
+			continue
+		}
+		p.flush(ph.Start)
+		p.nodes = append(p.nodes, ph)
+		p.start = p.pos
+	}
+	p.flush(length)
+	return p.nodes, nil
+}
+
+//placeholder parses {key,width:spec} at pos
+//	it returns nil if the text is not a placeholder, which is appended to literal instead
+func (p *parser) placeholder() (*Placeholder, error) {
+	str := p.str
+	length := len(str)
+	open, close := p.opts.open, p.opts.close
+	start := p.pos
+	p.ph_start = start
+
+	p.pos++
+	if p.pos == length {
+		return nil, p.error(KindUnclosedBrace)
+	}
+
+	if !is_key_char(str[p.pos]) {
+		//detectd '{' but not detectd any legal key here
+		if p.opts.strict {
+			p.pos = start
+			return nil, p.error(KindUnescapedBrace)
+		}
+		p.literal = append(p.literal, open)
+		return nil, nil
+	}
+
+	//get keys in {}, like {0}, {Name} or a path like {User.Items[0].Name}
+	key_start := p.pos
+	path := p.scan_key()
+	if p.pos == length {
+		return nil, p.error(KindUnclosedBrace)
+	}
+	ph := &Placeholder{Key: str[key_start:p.pos], Path: path, Index: -1, Start: start}
+	if is_number(path[0].Name) {
+		ph.Index = path[0].Index
+		if max := p.opts.limits.max_index(); ph.Index < 0 || ph.Index > max {
+			p.pos = key_start
+			return nil, p.error_detail(KindNumberTooLarge, fmt.Errorf("index %s exceeds MaxIndex %d", path[0].Name, max))
+		}
+	}
+
+	//remove all space
+	if err := p.skip_space(); err != nil {
+		return nil, err
+	}
+
+	//get number after ',' to leftpad or rightpad space
+	//	like {0,10} {0,-10}, or with fill and align like {0,^10} {0,*>10} {0,.<10}
+	if str[p.pos] == ',' {
+		p.pos++
+		if err := p.skip_space(); err != nil {
+			return nil, err
+		}
+		if fill, size := utf8.DecodeRuneInString(str[p.pos:]); p.pos+size < length && p.is_align_char(str[p.pos+size]) {
+			if fill == utf8.RuneError && size == 1 {
+				//a fill which is not valid UTF-8 would be padded as U+FFFD silently
+				return nil, p.error(KindInvalidPlaceholder)
+			}
+			ph.Fill = fill
+			ph.Align = align_of(str[p.pos+size])
+			p.pos += size + 1
+		} else if p.is_align_char(str[p.pos]) {
+			ph.Align = align_of(str[p.pos])
+			p.pos++
+		} else if str[p.pos] == '-' {
+			ph.Align = AlignLeft
+			p.pos++
+		}
+		if p.pos == length {
+			return nil, p.error(KindUnclosedBrace)
+		}
+		var err error
+		if str[p.pos] != '.' {
+			if ph.Width, err = p.number("width"); err != nil {
+				return nil, err
+			}
+		}
+
+		//get max width after '.' to truncate, like {0,-20.20} or {0,.20!} with ellipsis
+		if p.pos < length && str[p.pos] == '.' {
+			p.pos++
+			if ph.MaxWidth, err = p.number("max width"); err != nil {
+				return nil, err
+			}
+			if p.pos < length && str[p.pos] == '!' {
+				ph.Ellipsis = true
+				p.pos++
+			}
+		}
+		if err := p.skip_space(); err != nil {
+			return nil, err
+		}
+	}
+
+	//get spec after :
+	if str[p.pos] == ':' {
+		p.pos++
+		var spec []byte
+		for {
+			if p.pos == length {
+				return nil, p.error(KindUnclosedBrace)
+			}
+			ch := str[p.pos]
+			p.pos++
+
+			if ch == open {
+				//escape char for {{
+				if p.pos < length && str[p.pos] == open {
+					p.pos++
+				} else {
+					p.pos--
+					return nil, p.error(KindInvalidPlaceholder)
+				}
+			}
+
+			//escape char for }}
+			if ch == close {
+				if p.pos < length && str[p.pos] == close {
+					p.pos++
+				} else {
+					p.pos--
+					break
+				}
+			}
+			spec = append(spec, ch)
+		}
+		ph.Spec = string(spec)
+	}
+
+	//already handle {key,width:spec , should get } here
+	if str[p.pos] != close {
+		//not a placeholder, keep the text as it is
+		if p.opts.strict {
+			return nil, p.error(KindInvalidPlaceholder)
+		}
+		p.literal = append(p.literal, str[start:p.pos]...)
+		return nil, nil
+	}
+	p.pos++
+	ph.Raw = str[start:p.pos]
+	return ph, nil
+}
+
+//is_key_char reports whether ch could be a part of a key in {}
+func is_key_char(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z') || ch == '_'
+}
+
+//number parses the digits at pos as a width, which should not be larger than MaxWidth
+func (p *parser) number(name string) (int, error) {
+	str := p.str
+	if p.pos == len(str) {
+		return 0, p.error(KindUnclosedBrace)
+	}
+	if str[p.pos] < '0' || str[p.pos] > '9' {
+		return 0, p.error(KindInvalidPlaceholder)
+	}
+	start := p.pos
+	for p.pos < len(str) && str[p.pos] >= '0' && str[p.pos] <= '9' {
+		p.pos++
+	}
+	number := parse_number(str[start:p.pos])
+	if max := p.opts.limits.max_width(); number < 0 || number > max {
+		err := fmt.Errorf("%s %s exceeds MaxWidth %d", name, str[start:p.pos], max)
+		p.pos = start
+		return 0, p.error_detail(KindNumberTooLarge, err)
+	}
+	return number, nil
+}
+
+//is_align_char reports whether ch is an align char, which could not be a delimiter at the same time
+func (p *parser) is_align_char(ch byte) bool {
+	return (ch == '<' || ch == '>' || ch == '^') && ch != p.opts.open && ch != p.opts.close
+}
+
+func align_of(ch byte) Alignment {
+	switch ch {
+	case '<':
+		return AlignLeft
+	case '^':
+		return AlignCenter
+	}
+	return AlignRight
+}
+
+//max_number is the largest index or width accepted in a placeholder, whatever the Limits are
+const max_number = 1<<31 - 1
+
+//is_number reports whether key is made of digits only
+func is_number(key string) bool {
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return false
+		}
+	}
+	return len(key) > 0
+}
+
+//parse_number converts digits to int, -1 if it is larger than max_number
+func parse_number(digits string) int {
+	number := 0
+	for i := 0; i < len(digits); i++ {
+		if number > (max_number-int(digits[i]-'0'))/10 {
+			return -1
+		}
+		number = number*10 + int(digits[i]-'0')
+	}
+	return number
+}
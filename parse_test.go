@@ -0,0 +1,108 @@
+package strfmt
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	nodes, err := Parse("Today is {{a}} {0,-10} {day : 2006-01-02}}} }}")
+	if err != nil {
+		t.Error("Test_Parse throw error " + err.Error())
+		t.FailNow()
+	}
+	if len(nodes) != 5 {
+		t.Errorf("Test_Parse expect 5 nodes, got %d", len(nodes))
+		t.FailNow()
+	}
+
+	lit, ok := nodes[0].(*Literal)
+	if !ok || lit.Value != "Today is {a} " || lit.Raw != "Today is {{a}} " || lit.Offset() != 0 {
+		t.Errorf("Test_Parse unexpected literal %+v", nodes[0])
+	}
+
+	ph, ok := nodes[1].(*Placeholder)
+	if !ok || ph.Key != "0" || ph.Index != 0 || ph.Width != 10 || ph.Align != AlignLeft || ph.Text() != "{0,-10}" || ph.Offset() != 15 {
+		t.Errorf("Test_Parse unexpected placeholder %+v", nodes[1])
+	}
+
+	ph, ok = nodes[3].(*Placeholder)
+	if !ok || ph.Key != "day" || ph.Index != -1 || ph.Spec != " 2006-01-02}" {
+		t.Errorf("Test_Parse unexpected placeholder %+v", nodes[3])
+	}
+}
+
+func Test_Parse_same_rules(t *testing.T) {
+	//Format and FormatMap share the same scanner, so recovery of malformed text is the same
+	strs := []string{"Today is }", "Today is {} Day", "Today is { Day", "Today is {0 x} Day"}
+	args := map[string]string{"0": "wonderful"}
+	for _, str := range strs {
+		res, err := Format(str, "wonderful")
+		if err != nil {
+			t.Error("Test_Parse_same_rules throw error " + err.Error())
+		}
+		res_map, err := FormatMap(str, &args)
+		if err != nil {
+			t.Error("Test_Parse_same_rules throw error " + err.Error())
+		}
+		if res != str || res_map != str {
+			t.Error("Test_Parse_same_rules [" + str + "] got " + res + " and " + res_map)
+		}
+	}
+}
+
+func Test_Parse_large_numbers(t *testing.T) {
+	args := make([]string, 301)
+	for i := range args {
+		args[i] = strconv.Itoa(i)
+	}
+	res, err := Format("{300}|{0,300}|{256,-3}|", args...)
+	if err != nil {
+		t.Error("Test_Parse_large_numbers throw error " + err.Error())
+	}
+	if res != "300|"+strings.Repeat(" ", 299)+"0|256|" {
+		t.Error("Test_Parse_large_numbers unexpected result " + res)
+	}
+
+	for _, str := range []string{"{65536}", "{0,65536}", "{99999999999999999999}", "{0,99999999999999999999}"} {
+		_, err = Compile(str)
+		if !errors.Is(err, ErrNumberTooLarge) {
+			t.Error("Test_Parse_large_numbers ["+str+"] should be ErrNumberTooLarge ", err)
+		}
+	}
+
+	_, err = Compile("{0,70000}", WithLimits(Limits{MaxWidth: -1}))
+	if err != nil {
+		t.Error("Test_Parse_large_numbers throw error " + err.Error())
+	}
+}
+
+func Test_Parse_fill_align(t *testing.T) {
+	res, err := Format("[{0,^11}][{1,0>6}][{2,.<12}][{3,*^8:2006}][{0,-<11}][{1,>4}]", "Title", "42", "Chapter 1", "Mon, 02 Aug 2021 10:04:05 +0000")
+	if err != nil {
+		t.Error("Test_Parse_fill_align throw error " + err.Error())
+	}
+	if res != "[   Title   ][000042][Chapter 1...][**2021**][Title------][  42]" {
+		t.Error("Test_Parse_fill_align unexpected result " + res)
+	}
+
+	args := map[string]string{"title": "日本"}
+	res, err = FormatMap("[{title,・^8}]", &args)
+	if err != nil || res != "[・日本・]" {
+		t.Error("Test_Parse_fill_align unexpected result "+res, err)
+	}
+
+	nodes, _ := Parse("{0,★^10}")
+	if ph := nodes[0].(*Placeholder); ph.Fill != '★' || ph.Align != AlignCenter || ph.Width != 10 {
+		t.Errorf("Test_Parse_fill_align unexpected placeholder %+v", ph)
+	}
+
+	if _, err = Parse("{0,\xff>3}"); !errors.Is(err, ErrInvalidPlaceholder) {
+		t.Error("Test_Parse_fill_align should be ErrInvalidPlaceholder ", err)
+	}
+	if _, err = FormatAny("{0,\xff>3}", 1); !errors.Is(err, ErrInvalidPlaceholder) {
+		t.Error("Test_Parse_fill_align should be ErrInvalidPlaceholder ", err)
+	}
+}
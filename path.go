@@ -0,0 +1,331 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+//Segment is one step of a placeholder key, like User, Address, [0] or ["env"] of {User.Address[0]} or {Labels["env"]}
+//	Index is the number in [], or the number of a key like {0}, otherwise -1
+//	Name is the field name or map key, the number text for an index
+type Segment struct {
+	Name  string
+	Index int
+}
+
+//scan_key reads a key like Name, User.Address.City, Items[0].Name or Labels["env"] at pos
+//	it stops before the first char which could not continue the key
+func (p *parser) scan_key() []Segment {
+	str := p.str
+	length := len(str)
+
+	name_start := p.pos
+	for p.pos < length && is_key_char(str[p.pos]) {
+		p.pos++
+	}
+	name := str[name_start:p.pos]
+	path := []Segment{{Name: name, Index: -1}}
+	if is_number(name) {
+		path[0].Index = parse_number(name)
+	}
+
+	for p.pos < length {
+		switch str[p.pos] {
+		case '.':
+			start := p.pos + 1
+			end := start
+			for end < length && is_key_char(str[end]) {
+				end++
+			}
+			if end == start {
+				return path
+			}
+			path = append(path, Segment{Name: str[start:end], Index: -1})
+			p.pos = end
+		case '[':
+			seg, end, ok := scan_bracket(str, p.pos)
+			if !ok {
+				return path
+			}
+			path = append(path, seg)
+			p.pos = end
+		default:
+			return path
+		}
+	}
+	return path
+}
+
+//scan_bracket reads [0] or ["key"] at pos, end is the offset after ']'
+func scan_bracket(str string, pos int) (Segment, int, bool) {
+	length := len(str)
+	pos++
+	if pos < length && str[pos] >= '0' && str[pos] <= '9' {
+		start := pos
+		for pos < length && str[pos] >= '0' && str[pos] <= '9' {
+			pos++
+		}
+		index := parse_number(str[start:pos])
+		if pos == length || str[pos] != ']' || index < 0 {
+			return Segment{}, 0, false
+		}
+		return Segment{Name: str[start:pos], Index: index}, pos + 1, true
+	}
+
+	if pos < length && str[pos] == '"' {
+		start := pos
+		pos++
+		for pos < length && str[pos] != '"' {
+			if str[pos] == '\\' {
+				pos++
+			}
+			pos++
+		}
+		if pos+1 >= length || str[pos+1] != ']' {
+			return Segment{}, 0, false
+		}
+		name, err := strconv.Unquote(str[start : pos+1])
+		if err != nil {
+			return Segment{}, 0, false
+		}
+		return Segment{Name: name, Index: -1}, pos + 2, true
+	}
+	return Segment{}, 0, false
+}
+
+//path_text renders path like User.Items[0].Labels["env"] for error messages
+func path_text(path []Segment) string {
+	var b strings.Builder
+	for i, seg := range path {
+		switch {
+		case i > 0 && seg.Index >= 0:
+			b.WriteString("[" + seg.Name + "]")
+		case is_identifier(seg.Name):
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(seg.Name)
+		default:
+			b.WriteString("[" + strconv.Quote(seg.Name) + "]")
+		}
+	}
+	return b.String()
+}
+
+func is_identifier(name string) bool {
+	for i := 0; i < len(name); i++ {
+		if !is_key_char(name[i]) {
+			return false
+		}
+	}
+	return len(name) > 0
+}
+
+//resolve walks path from v through pointers, interfaces, structs, maps, slices, arrays and methods
+//	info is the struct field of the last segment, nil if the last segment is not a struct field
+//	the error names the first segment which could not be resolved
+func (o *options) resolve(v reflect.Value, path []Segment) (reflect.Value, *field_info, error) {
+	var info *field_info
+	for i, seg := range path {
+		fail := func(format string, args ...interface{}) (reflect.Value, *field_info, error) {
+			return reflect.Value{}, nil, &path_error{path: path_text(path[:i+1]), err: fmt.Errorf(format, args...)}
+		}
+		info = nil
+
+		var err error
+		v, err = o.indirect(v)
+		if err != nil {
+			return reflect.Value{}, nil, &path_error{path: path_text(path[:i+1]), err: err}
+		}
+		if !v.IsValid() {
+			return fail("nil value before %q", seg.Name)
+		}
+
+		switch v.Kind() {
+		case reflect.Struct:
+			field, field_info, err := o.field_by_name(v, seg.Name)
+			if err == nil {
+				v, info = field, field_info
+				continue
+			}
+			var ambiguous *ambiguous_error
+			method, ok := method_of(v, seg.Name)
+			if !ok || errors.As(err, &ambiguous) {
+				return reflect.Value{}, nil, &path_error{path: path_text(path[:i+1]), err: err}
+			}
+			if v, err = call_method(method); err != nil {
+				return reflect.Value{}, nil, &path_error{path: path_text(path[:i+1]), err: err}
+			}
+		case reflect.Map:
+			key, ok := map_key(v.Type().Key(), seg)
+			if !ok {
+				return fail("key %q could not be converted to %s", seg.Name, v.Type().Key())
+			}
+			value := v.MapIndex(key)
+			if !value.IsValid() {
+				return fail("no key %q in %s", seg.Name, v.Type())
+			}
+			v = value
+		case reflect.Slice, reflect.Array, reflect.String:
+			if seg.Index < 0 {
+				return fail("%s could only be indexed by number, not %q", v.Type(), seg.Name)
+			}
+			if seg.Index >= v.Len() {
+				return fail("index %d out of range with length %d", seg.Index, v.Len())
+			}
+			v = v.Index(seg.Index)
+		default:
+			method, ok := method_of(v, seg.Name)
+			if !ok {
+				return fail("could not get %q from %s", seg.Name, v.Type())
+			}
+			if v, err = call_method(method); err != nil {
+				return reflect.Value{}, nil, &path_error{path: path_text(path[:i+1]), err: err}
+			}
+		}
+	}
+	return v, info, nil
+}
+
+//method_of gets the exported method named name of v, methods of pointer receivers are called on a copy if v is not addressable
+//	a method could be used by placeholders if it takes no args, and returns a value or a value and an error
+func method_of(v reflect.Value, name string) (reflect.Value, bool) {
+	if !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	m := v.MethodByName(name)
+	if !m.IsValid() && v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+		m = pointer_to(v).MethodByName(name)
+	}
+	if !m.IsValid() || !is_getter(m.Type(), 0) {
+		return reflect.Value{}, false
+	}
+	return m, true
+}
+
+//pointer_to returns a pointer to v, or to a copy of v if v is not addressable
+func pointer_to(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+	return c
+}
+
+//is_getter reports whether a method of type typ could be used by placeholders
+//	in is 1 for a method got from a type, whose receiver is the first in, or 0 for a method got from a value
+func is_getter(typ reflect.Type, in int) bool {
+	return typ.NumIn() == in && (typ.NumOut() == 1 || (typ.NumOut() == 2 && typ.Out(1) == error_type))
+}
+
+//call_method calls a method got by method_of, the error it returns is returned as well
+func call_method(m reflect.Value) (reflect.Value, error) {
+	out := m.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	return out[0], nil
+}
+
+var error_type = reflect.TypeOf((*error)(nil)).Elem()
+
+//path_error tells which segment of a path could not be resolved
+type path_error struct {
+	path string
+	err  error
+}
+
+func (e *path_error) Error() string {
+	return "path " + e.path + ": " + e.err.Error()
+}
+
+func (e *path_error) Unwrap() error {
+	return e.err
+}
+
+//indirect gets the value in pointers and interfaces, an invalid Value if any of them is nil
+//	more than MaxDepth of them in a row is an error, like an interface{} holding a pointer to itself
+func (o *options) indirect(v reflect.Value) (reflect.Value, error) {
+	max := o.limits.max_depth()
+	for depth := 0; v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface); depth++ {
+		if depth == max {
+			return reflect.Value{}, fmt.Errorf("more than %d pointers or interfaces in a row", max)
+		}
+		if v.IsNil() {
+			return reflect.Value{}, nil
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+//addressable copies v to a new variable if fields which are not exported could be read,
+//	so that fields got from it are addressable
+func (o *options) addressable(v reflect.Value) reflect.Value {
+	if !o.unexported || !v.IsValid() || v.CanAddr() || (v.Kind() != reflect.Struct && v.Kind() != reflect.Array) {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+//map_key converts a segment to a key of map key type typ
+func map_key(typ reflect.Type, seg Segment) (reflect.Value, bool) {
+	switch typ.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(seg.Name).Convert(typ), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(seg.Name, 10, 64)
+		if err != nil || reflect.Zero(typ).OverflowInt(n) {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(n).Convert(typ), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(seg.Name, 10, 64)
+		if err != nil || reflect.Zero(typ).OverflowUint(n) {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(n).Convert(typ), true
+	case reflect.Interface:
+		if seg.Index >= 0 {
+			return reflect.ValueOf(seg.Index), true
+		}
+		return reflect.ValueOf(seg.Name), true
+	}
+	return reflect.Value{}, false
+}
+
+//interface_of returns the value in v as an interface{} to format, fields which are not exported included
+//	only fields let by WithUnexported(true) could get here, they are read through their address if they have one
+func interface_of(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem().Interface()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return fmt.Sprint(v)
+}
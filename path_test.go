@@ -0,0 +1,111 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type Address struct {
+	City   string
+	Street *string
+}
+
+type Item struct {
+	Name  string
+	Count int
+}
+
+type Order struct {
+	User struct {
+		Name    string
+		Address *Address
+	}
+	Items  []Item
+	Labels map[string]string
+	Codes  map[int]string
+	Extra  interface{}
+}
+
+func Test_FormatData_path(t *testing.T) {
+	order := &Order{
+		Items:  []Item{{Name: "tea", Count: 2}, {Name: "cake", Count: 1}},
+		Labels: map[string]string{"env": "prod", "a.b": "dotted"},
+		Codes:  map[int]string{7: "seven"},
+		Extra:  map[string]interface{}{"tags": []string{"x", "y"}},
+	}
+	order.User.Name = "Mary"
+	order.User.Address = &Address{City: "Paris"}
+
+	res, err := FormatData(`{User.Name} {User.Address.City,-6}|{Items[1].Name} x{Items[1].Count} {Labels["env"]} {Labels["a.b"]} {Codes[7]} {Extra.tags[1]} {Items[0].Name[0]}`, order)
+	if err != nil {
+		t.Error("Test_FormatData_path throw error " + err.Error())
+	}
+	if res != "Mary Paris |cake x1 prod dotted seven y 116" {
+		t.Error("Test_FormatData_path unexpected result " + res)
+	}
+
+	res, err = FormatAny("{0.Name} has {1[0]}", order.Items[0], []int{3})
+	if err != nil || res != "tea has 3" {
+		t.Error("Test_FormatData_path unexpected result "+res, err)
+	}
+
+	cases := map[string]string{
+		"{User.Adress.City}":    "path User.Adress: no field \"Adress\"",
+		"{Items[5].Name}":       "path Items[5]: index 5 out of range with length 2",
+		`{Labels["dev"]}`:       "path Labels.dev: no key \"dev\"",
+		"{User.Address.Street}": "",
+		"{Items.Name}":          "path Items.Name: []strfmt.Item could only be indexed by number",
+	}
+	f := New(WithMissing(MissingError))
+	for str, msg := range cases {
+		_, err = f.FormatData(str, order)
+		if msg == "" {
+			if err != nil {
+				t.Error("Test_FormatData_path ["+str+"] throw error ", err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrMissingKey) || !strings.Contains(err.Error(), msg) {
+			t.Error("Test_FormatData_path ["+str+"] unexpected error ", err)
+		}
+		fmt.Println(err)
+	}
+
+	//paths which could not be resolved are kept by default
+	res, err = FormatData("{User.Adress.City}", order)
+	if err != nil || res != "{User.Adress.City}" {
+		t.Error("Test_FormatData_path unexpected result "+res, err)
+	}
+}
+
+func Test_Parse_path(t *testing.T) {
+	nodes, err := Parse(`{User.Items[12]["k\"ey"].Name,-4}{a.}{b[x]}`)
+	if err != nil {
+		t.Error("Test_Parse_path throw error " + err.Error())
+		t.FailNow()
+	}
+	ph := nodes[0].(*Placeholder)
+	if ph.Key != `User.Items[12]["k\"ey"].Name` || len(ph.Path) != 5 || ph.Path[2].Index != 12 || ph.Path[3].Name != `k"ey` || ph.Width != 4 {
+		t.Errorf("Test_Parse_path unexpected placeholder %+v", ph)
+	}
+	if path_text(ph.Path) != `User.Items[12]["k\"ey"].Name` {
+		t.Error("Test_Parse_path unexpected path text " + path_text(ph.Path))
+	}
+	if lit := nodes[1].(*Literal); lit.Value != "{a.}{b[x]}" {
+		t.Error("Test_Parse_path unexpected literal " + lit.Value)
+	}
+}
+
+func Test_FormatData_method(t *testing.T) {
+	invoice := &Invoice{Customer: &Customer{FirstName: "Mary"}, Items: []string{"tea"}}
+	res, err := New(WithMissing(MissingError)).FormatData("{Title} has {Count} item", invoice)
+	if err != nil || res != "Invoice Mary has 1 item" {
+		t.Error("Test_FormatData_method unexpected result "+res, err)
+	}
+	_, err = New(WithMissing(MissingError)).FormatData("{Title}", Invoice{})
+	if !errors.Is(err, ErrMissingKey) || !strings.Contains(err.Error(), "path Title: no customer") {
+		t.Error("Test_FormatData_method should be ErrMissingKey ", err)
+	}
+}
@@ -0,0 +1,256 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//picture tokens
+const (
+	token_literal = iota
+	//token_zero is '0', a digit always shown
+	token_zero
+	//token_digit is '#', a digit shown if it is significant
+	token_digit
+	token_point
+)
+
+type picture_token struct {
+	kind int
+	text string
+}
+
+//picture is one section of a custom numeric spec like #,##0.00
+type picture struct {
+	integer   []picture_token
+	fraction  []picture_token
+	has_point bool
+	//placeholders before the point, and the min digits from the first '0'
+	int_places int
+	int_min    int
+	//placeholders after the point, and the min digits up to the last '0'
+	frac_places int
+	frac_min    int
+	group       bool
+	//scale is the power of 10 the value is multiplied by, '%' is 2, '‰' is 3, each ',' before the point is -3
+	scale int
+}
+
+//errNoDigit is returned for a custom numeric spec without any digit, which is more likely a typo than a picture
+var errNoDigit = errors.New("a custom numeric spec needs '0' or '#' in its first section")
+
+//errUnquoted is returned for a letter or a digit 1-9 out of quotes in a custom numeric spec,
+//	so a time layout like 2006-01-02 or a typo on a number is not taken as a picture
+var errUnquoted = errors.New("letters and digits 1-9 in a custom numeric spec should be quoted or escaped by '\\'")
+
+//format_picture renders d with a custom numeric spec like .NET does, like #,##0.00;(#,##0.00);-
+//	sections split by ';' are for positive, negative and zero values, a negative one is rendered without its sign
+//	with one section, negative values get a '-', with two the zero values use the first section,
+//	a value which is zero after rounding by its section uses the zero section
+//	'0' is a digit always shown, '#' is a digit shown if significant, '.' is the point,
+//	',' between digits groups by 3, ',' right before the point divides by 1000, '%' and '‰' multiply by 100 and 1000,
+//	text in quotes like 'USD' or after '\' is literal, other chars are literal as well except letters and digits 1-9,
+//	sections other than the first could be literal only, like '-' of zero values
+//	digits are rounded by mode, see RoundingMode
+func format_picture(d decimal, spec string, mode RoundingMode) (string, error) {
+	sections := split_sections(spec)
+	pictures := make([]*picture, len(sections))
+	for i, section := range sections {
+		if len(section) == 0 && i > 0 {
+			continue
+		}
+		p, err := parse_picture(section)
+		if err != nil {
+			return "", err
+		}
+		pictures[i] = p
+	}
+	if pictures[0].int_places+pictures[0].frac_places == 0 {
+		return "", errNoDigit
+	}
+
+	p, sign := pictures[0], d.neg
+	if d.neg && len(pictures) > 1 && pictures[1] != nil {
+		p, sign = pictures[1], false
+	}
+	rounded := d.shift(p.scale).round(p.frac_places, mode)
+	if rounded.is_zero() {
+		sign = false
+		if len(pictures) > 2 && pictures[2] != nil {
+			p = pictures[2]
+			rounded = d.shift(p.scale).round(p.frac_places, mode)
+		}
+	}
+	value := p.render(rounded)
+	if sign {
+		return "-" + value, nil
+	}
+	return value, nil
+}
+
+//is_picture reports whether spec is a custom numeric spec which format_picture accepts
+func is_picture(spec string) bool {
+	_, err := format_picture(decimal{}, spec, RoundHalfUp)
+	return err == nil
+}
+
+//split_sections splits spec by ';' which is not quoted or escaped, 3 sections at most
+func split_sections(spec string) []string {
+	var sections []string
+	start := 0
+	var quote byte
+	for i := 0; i < len(spec); i++ {
+		ch := spec[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\\':
+			i++
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == ';' && len(sections) < 2:
+			sections = append(sections, spec[start:i])
+			start = i + 1
+		}
+	}
+	return append(sections, spec[start:])
+}
+
+//parse_picture parses one section of a custom numeric spec
+func parse_picture(section string) (*picture, error) {
+	p := &picture{}
+	tokens := &p.integer
+	literal := func(text string) {
+		*tokens = append(*tokens, picture_token{kind: token_literal, text: text})
+	}
+	//pending commas after the last placeholder, they scale if the point or the end comes next
+	commas := 0
+	digit := func(kind int) {
+		if commas > 0 && !p.has_point && p.int_places > 0 {
+			p.group = true
+		}
+		commas = 0
+		*tokens = append(*tokens, picture_token{kind: kind})
+		if !p.has_point {
+			p.int_places++
+			if kind == token_zero && p.int_min == 0 {
+				p.int_min = 1
+			} else if p.int_min > 0 {
+				p.int_min++
+			}
+		} else {
+			p.frac_places++
+			if kind == token_zero {
+				p.frac_min = p.frac_places
+			}
+		}
+	}
+
+	for i := 0; i < len(section); i++ {
+		ch := section[i]
+		switch {
+		case ch == '0':
+			digit(token_zero)
+		case ch == '#':
+			digit(token_digit)
+		case ch == '.' && !p.has_point:
+			p.scale -= 3 * commas
+			commas = 0
+			p.has_point = true
+			*tokens = append(*tokens, picture_token{kind: token_point})
+			tokens = &p.fraction
+		case ch == ',' && !p.has_point:
+			if p.int_places > 0 {
+				commas++
+			}
+		case ch == '%':
+			p.scale += 2
+			literal("%")
+		case strings.HasPrefix(section[i:], "‰"):
+			p.scale += 3
+			literal("‰")
+			i += len("‰") - 1
+		case ch == '\\':
+			if i+1 < len(section) {
+				i++
+				literal(section[i : i+1])
+			}
+		case ch == '\'' || ch == '"':
+			end := strings.IndexByte(section[i+1:], ch)
+			if end < 0 {
+				end = len(section) - i - 1
+			}
+			literal(section[i+1 : i+1+end])
+			i += end + 1
+		default:
+			r, size := utf8.DecodeRuneInString(section[i:])
+			if unicode.IsLetter(r) || (r >= '1' && r <= '9') {
+				return nil, fmt.Errorf("%w, not %q", errUnquoted, r)
+			}
+			literal(section[i : i+size])
+			i += size - 1
+		}
+	}
+	if !p.has_point {
+		p.scale -= 3 * commas
+	}
+	return p, nil
+}
+
+//render renders an absolute d rounded by the picture
+func (p *picture) render(d decimal) string {
+	integer := strings.TrimLeft(d.integer(), "0")
+	if len(integer) < p.int_min {
+		integer = strings.Repeat("0", p.int_min-len(integer)) + integer
+	}
+	fraction := d.fraction()
+	if len(fraction) < p.frac_min {
+		fraction += strings.Repeat("0", p.frac_min-len(fraction))
+	}
+
+	var b strings.Builder
+	//digits are put into placeholders from the right, the first placeholder gets all digits left over
+	next := 0
+	place := 0
+	put_digits := func(end int) {
+		for ; next < end; next++ {
+			b.WriteByte(integer[next])
+			if rest := len(integer) - 1 - next; p.group && rest > 0 && rest%3 == 0 {
+				b.WriteByte(',')
+			}
+		}
+	}
+	for _, token := range p.integer {
+		switch token.kind {
+		case token_literal:
+			b.WriteString(token.text)
+		case token_zero, token_digit:
+			place++
+			put_digits(len(integer) - (p.int_places - place))
+		case token_point:
+			put_digits(len(integer))
+			if len(fraction) > 0 {
+				b.WriteByte('.')
+			}
+		}
+	}
+
+	place = 0
+	for _, token := range p.fraction {
+		switch token.kind {
+		case token_literal:
+			b.WriteString(token.text)
+		case token_zero, token_digit:
+			if place < len(fraction) {
+				b.WriteByte(fraction[place])
+			}
+			place++
+		}
+	}
+	return b.String()
+}
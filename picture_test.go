@@ -0,0 +1,108 @@
+package strfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func Test_format_picture(t *testing.T) {
+	cases := []struct {
+		spec   string
+		arg    interface{}
+		result string
+	}{
+		{"#,##0.00", 1234567.891, "1,234,567.89"},
+		{"#,##0.00", 0.5, "0.50"},
+		{"#,##0.00", -42, "-42.00"},
+		{"#,##0.00;(#,##0.00);-", -1234.5, "(1,234.50)"},
+		{"#,##0.00;(#,##0.00);-", 0, "-"},
+		{"#,##0.00;(#,##0.00);-", 0.001, "-"},
+		{"#,##0.00;(#,##0.00);-", 12, "12.00"},
+		{"0.00;'minus '0.00", -3.14159, "minus 3.14"},
+		{"0.00 \\k\\m", 12.5, "12.50 km"},
+		{"€ #,##0.00", 1234.5, "€ 1,234.50"},
+		{"#.##", 2.675, "2.68"},
+		{"#.##", 0.5, ".5"},
+		{"0.###", 2, "2"},
+		{"00000", 42, "00042"},
+		{"0", 12345, "12345"},
+		{"(###) ###-####", 5551234567, "(555) 123-4567"},
+		{"#,##0,", 1234567, "1,235"},
+		{"0,,.0", 987654321, "987.7"},
+		{"0.0%", 0.1234, "12.3%"},
+		{"0.0‰", 0.01234, "12.3‰"},
+		{"'USD' #,##0", 1500, "USD 1,500"},
+		{"\\#0", 7, "#7"},
+		{"0.00 \"%\"", 0.5, "0.50 %"},
+		{"#,##0.00", json.Number("12345.675"), "12,345.68"},
+		{"#,##0", new(big.Int).Lsh(big.NewInt(1), 70), "1,180,591,620,717,411,303,424"},
+		{"0.0000", big.NewRat(1, 3), "0.3333"},
+		{"0.0", float32(0.1), "0.1"},
+	}
+	o := new_options(nil)
+	for _, c := range cases {
+		res, kind, err := o.format_value(c.arg, c.spec)
+		if err != nil {
+			t.Errorf("Test_format_picture [%s] throw error %s, kind %s", c.spec, err.Error(), kind)
+			continue
+		}
+		if res != c.result {
+			t.Errorf("Test_format_picture [%s] unexpected result %s", c.spec, res)
+		}
+	}
+
+	for _, arg := range []interface{}{json.Number("1e9000000000000000"), json.Number("1e900000000"), json.Number("12a")} {
+		if _, err := FormatAny("{0:0.00}", arg); !errors.Is(err, ErrBadSpec) {
+			t.Errorf("Test_format_picture [%s] should be ErrBadSpec %v", arg, err)
+		}
+	}
+	if _, _, err := o.format_value(1, "(-)"); !errors.Is(err, errNoDigit) {
+		t.Error("Test_format_picture should be errNoDigit ", err)
+	}
+	for _, spec := range []string{"Mon Jan", "2006-01-02", "0.00 km", "#,##0;minus #", "0;0;none"} {
+		if _, _, err := o.format_value(1, spec); !errors.Is(err, errUnquoted) {
+			t.Errorf("Test_format_picture [%s] should be errUnquoted %v", spec, err)
+		}
+	}
+}
+
+func Test_parse_decimal(t *testing.T) {
+	cases := []struct {
+		text     string
+		integer  string
+		fraction string
+	}{
+		{"0", "", ""},
+		{"-0.000", "", ""},
+		{"120", "120", ""},
+		{"001.2500", "1", "25"},
+		{"0.05", "", "05"},
+		{"1.25e+02", "125", ""},
+		{"1.5E-3", "", "0015"},
+	}
+	for _, c := range cases {
+		d, err := parse_decimal(c.text)
+		if err != nil || d.integer() != c.integer || d.fraction() != c.fraction {
+			t.Errorf("Test_parse_decimal [%s] unexpected decimal %+v", c.text, d)
+		}
+	}
+	for _, text := range []string{"", "-", "+Inf", "NaN", "1.2.3", "1e"} {
+		if _, err := parse_decimal(text); !errors.Is(err, errNotDecimal) {
+			t.Errorf("Test_parse_decimal [%s] should be errNotDecimal %v", text, err)
+		}
+	}
+	for _, text := range []string{"1e10001", "-1e-10001", "1e9000000000000000", "1" + strings.Repeat("0", 10001)} {
+		if _, err := parse_decimal(text); !errors.Is(err, errDecimalRange) {
+			t.Errorf("Test_parse_decimal [%.20s] should be errDecimalRange %v", text, err)
+		}
+	}
+	if d, err := parse_decimal("1e10000"); err != nil || d.exp != 10001 {
+		t.Error("Test_parse_decimal unexpected decimal 1e10000 ", err)
+	}
+	if d, _ := parse_decimal("9.995"); d.round(2, RoundHalfUp).integer() != "10" || d.round(2, RoundHalfUp).fraction() != "" {
+		t.Errorf("Test_parse_decimal unexpected round %+v", d.round(2, RoundHalfUp))
+	}
+}
@@ -0,0 +1,287 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unicode/utf8"
+)
+
+//Template is a format string parsed once into a list of nodes
+//	a Template is immutable after Compile and safe for concurrent use
+//	rendering a Template only substitutes args, the format string is never rescanned
+type Template struct {
+	str   string
+	nodes []Node
+	opts  options
+}
+
+//Compile parses a format string into a Template
+//	str:target string
+//	syntax errors are returned here instead of on every render
+//	string format should be like : some description{0}{field,-20}{day:2006-01-02}
+//	opts apply to both parsing and rendering, like Compile(str, WithStrict(true))
+func Compile(str string, opts ...Option) (*Template, error) {
+	return New(opts...).Compile(str)
+}
+
+//String returns the format string the Template was compiled from
+func (t *Template) String() string {
+	return t.str
+}
+
+//Nodes returns the parsed nodes of the Template, which should not be modified
+func (t *Template) Nodes() []Node {
+	return t.nodes
+}
+
+//render formats one argument with spec and width of the placeholder
+func (t *Template) render(result []byte, ph *Placeholder, arg interface{}) ([]byte, error) {
+	value, kind, err := t.opts.format_value(arg, ph.Spec)
+	if err != nil {
+		return result, new_format_error(kind, t.str, ph, err)
+	}
+	return t.pad(result, ph, value), nil
+}
+
+//render_path resolves path from root and renders the value, a path which could not be resolved is missing
+func (t *Template) render_path(result []byte, ph *Placeholder, root reflect.Value, path []Segment) ([]byte, error) {
+	v, info, err := t.opts.resolve(root, path)
+	if err != nil {
+		return t.unresolved(result, ph, err)
+	}
+	return t.render_field(result, ph, v, info)
+}
+
+//unresolved renders a placeholder whose path could not be resolved because of err
+func (t *Template) unresolved(result []byte, ph *Placeholder, err error) ([]byte, error) {
+	var ambiguous *ambiguous_error
+	if t.opts.collisionError && errors.As(err, &ambiguous) {
+		return result, new_format_error(KindAmbiguousKey, t.str, ph, err)
+	}
+	return t.missing(result, ph, KindMissingKey, err)
+}
+
+//render_field renders a resolved value v, with the defaults in the tag of its field if info is not nil
+func (t *Template) render_field(result []byte, ph *Placeholder, v reflect.Value, info *field_info) ([]byte, error) {
+	if info == nil {
+		return t.render(result, ph, arg_of(v))
+	}
+
+	//defaults from the tag of the field
+	if info.err != nil {
+		return result, new_format_error(KindBadSpec, t.str, ph, info.err)
+	}
+	with := info.with_defaults(ph)
+	if info.omitempty && v.IsZero() {
+		return t.pad(result, with, ""), nil
+	}
+	if len(ph.Spec) == 0 {
+		if len(info.format) > 0 {
+			//fmt verbs apply to the value a pointer field points to, a nil one is the text of WithNil
+			value, err := t.opts.indirect(v)
+			if err != nil {
+				return result, new_format_error(KindBadSpec, t.str, ph, err)
+			}
+			if !value.IsValid() {
+				return t.pad(result, with, t.opts.nilText), nil
+			}
+			return t.pad(result, with, fmt.Sprintf(info.format, arg_of(value))), nil
+		}
+		if len(info.layout) > 0 {
+			with = copy_with_spec(with, info.layout)
+		}
+	}
+	return t.render(result, with, arg_of(v))
+}
+
+//copy_with_spec returns a copy of ph with spec
+func copy_with_spec(ph *Placeholder, spec string) *Placeholder {
+	with := *ph
+	with.Spec = spec
+	return &with
+}
+
+//pad appends arg to result with fill rune to fill the width of the placeholder
+//	arg is truncated to the max width of the placeholder first,
+//	the width of arg is measured by the WidthMode of options
+func (t *Template) pad(result []byte, ph *Placeholder, arg string) []byte {
+	if ph.MaxWidth > 0 {
+		ellipsis := ""
+		if ph.Ellipsis {
+			ellipsis = t.opts.ellipsis
+		}
+		arg = t.opts.widthMode.truncate(arg, ph.MaxWidth, ellipsis)
+	}
+
+	pad := ph.Width
+	if pad > 0 {
+		pad -= t.opts.widthMode.measure(arg)
+	}
+	fill := ph.Fill
+	if fill == 0 {
+		fill = t.opts.padRune
+	}
+
+	left, right := 0, 0
+	if pad > 0 {
+		switch ph.Align {
+		case AlignRight:
+			left = pad
+		case AlignLeft:
+			right = pad
+		case AlignCenter:
+			left = pad / 2
+			right = pad - left
+		}
+	}
+
+	//leftPad
+	result = t.fill(result, fill, left)
+
+	//append arg
+	result = append(result, arg...)
+
+	//rightPad
+	return t.fill(result, fill, right)
+}
+
+//fill appends fill to result for width columns, spaces make up the rest if fill is wider than 1 column
+func (t *Template) fill(result []byte, fill rune, width int) []byte {
+	if width <= 0 {
+		return result
+	}
+	fill_width := 1
+	if t.opts.widthMode == WidthDisplay {
+		fill_width = rune_width(fill)
+	}
+	if fill_width < 1 {
+		fill_width = 1
+	}
+	for j := 0; j < width/fill_width; j++ {
+		result = append_rune(result, fill)
+	}
+	for j := 0; j < width%fill_width; j++ {
+		result = append(result, ' ')
+	}
+	return result
+}
+
+//append_rune appends the utf8 encoding of r to result
+func append_rune(result []byte, r rune) []byte {
+	if r < utf8.RuneSelf {
+		return append(result, byte(r))
+	}
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+	return append(result, buf[:n]...)
+}
+
+//Format renders the Template with string args
+//	placeholders should be like {0}{1}, named placeholders and indexes out of range are missing,
+//	which are rendered by the MissingPolicy of WithMissing
+func (t *Template) Format(args ...string) (string, error) {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+	return t.FormatAny(values...)
+}
+
+//FormatAny renders the Template with typed args
+//	ints, floats, bools, time.Time, time.Duration, error, fmt.Stringer and []byte are converted natively,
+//	types could render themselves with the spec by Formattable, see Formattable for the order of methods
+//	a time.Time is rendered with the spec as time layout directly, like {0:2006-01-02}
+//	placeholders should be like {0}{1}, missing ones are rendered by the MissingPolicy of WithMissing
+func (t *Template) FormatAny(args ...interface{}) (string, error) {
+	var result []byte
+	var err error
+	for _, n := range t.nodes {
+		switch n := n.(type) {
+		case *Literal:
+			result = append(result, n.Value...)
+		case *Placeholder:
+			if n.Index < 0 || n.Index >= len(args) {
+				kind := KindIndexOutOfRange
+				if n.Index < 0 {
+					kind = KindMissingKey
+				}
+				result, err = t.missing(result, n, kind, nil)
+			} else if len(n.Path) > 1 {
+				//a path from an arg like {0.Name}
+				result, err = t.render_path(result, n, t.opts.addressable(reflect.ValueOf(args[n.Index])), n.Path[1:])
+			} else {
+				result, err = t.render(result, n, args[n.Index])
+			}
+			if err != nil {
+				return t.str, err
+			}
+		}
+	}
+	return string(result), nil
+}
+
+//FormatMap renders the Template with a map[string]string
+//	placeholders should be like {field}, keys not found in args are rendered by the MissingPolicy of WithMissing
+func (t *Template) FormatMap(args *map[string]string) (string, error) {
+	if args == nil {
+		return t.format_keys(func(string) (interface{}, bool) { return nil, false }, reflect.Value{})
+	}
+	return t.format_keys(func(key string) (interface{}, bool) {
+		arg, ok := (*args)[key]
+		return arg, ok
+	}, reflect.Value{})
+}
+
+//format_keys renders the Template with args got by their whole keys from get
+//	if get has no arg of a key like {user.Name}, its path is resolved from root instead, unless root is not valid
+func (t *Template) format_keys(get func(key string) (interface{}, bool), root reflect.Value) (string, error) {
+	var result []byte
+	var err error
+	for _, n := range t.nodes {
+		switch n := n.(type) {
+		case *Literal:
+			result = append(result, n.Value...)
+		case *Placeholder:
+			if arg, ok := get(n.Key); ok {
+				result, err = t.render(result, n, arg)
+			} else if root.IsValid() && len(n.Path) > 1 {
+				result, err = t.render_path(result, n, root, n.Path)
+			} else {
+				//not match means not match , MissingPolicy decides what to render
+				result, err = t.missing(result, n, KindMissingKey, nil)
+			}
+			if err != nil {
+				return t.str, err
+			}
+		}
+	}
+	return string(result), nil
+}
+
+//FormatData renders the Template with struct type data, or a map as the root of paths like {user.Name}
+//	placeholders should be like {field}, or paths like {User.Address.City}, {Items[0].Name} or {Labels["env"]}
+//	paths walk through pointers, interfaces, structs, maps, slices and arrays,
+//	and methods without args which return a value or a value and an error, like {Customer.FullName}
+//	fields of nested structs could be got by their own names like {City} as well, see FlattenMode for the rules
+//	fields could be renamed or skipped by strfmt tags, see WithJSONTags for the rules of tags
+//	placeholders which could not be resolved are missing, the error tells which segment fails
+//	nil values are rendered by WithNil, fields which are not exported are skipped unless WithUnexported(true)
+//	only the fields the placeholders name are read, the fields of a struct type are looked up once and cached
+func (t *Template) FormatData(args interface{}) (string, error) {
+	root := t.opts.addressable(reflect.ValueOf(args))
+	var result []byte
+	var err error
+	for _, n := range t.nodes {
+		switch n := n.(type) {
+		case *Literal:
+			result = append(result, n.Value...)
+		case *Placeholder:
+			result, err = t.render_path(result, n, root, n.Path)
+			if err != nil {
+				return t.str, err
+			}
+		}
+	}
+	return string(result), nil
+}
@@ -0,0 +1,121 @@
+package strfmt
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_Compile(t *testing.T) {
+	tmpl, err := Compile(format_today_rightpad)
+	if err != nil {
+		t.Error("Test_Compile throw error " + err.Error())
+		t.FailNow()
+	}
+	for _, day := range []string{"wonderful", "bad"} {
+		res, err := tmpl.Format(day)
+		if err != nil {
+			t.Error("Test_Compile throw error " + err.Error())
+		}
+		fmt.Println(res)
+	}
+
+	res, err := tmpl.Format()
+	if err == nil {
+		t.Error("Test_Compile [index out of range] should throw error ")
+	}
+	fmt.Println(res)
+}
+
+func Test_Compile_error(t *testing.T) {
+	_, err := Compile(format_error_without_complete_format)
+	if err == nil {
+		t.Error("Test_Compile_error [format_error_without_complete_format] should throw error ")
+	}
+
+	for _, str := range []string{format_error_only_left_brace, format_error_without_condition, format_error_only_right_brace, "Today is }"} {
+		_, err = Compile(str)
+		if err != nil {
+			t.Error("Test_Compile_error [" + str + "] should not throw error " + err.Error())
+		}
+	}
+}
+
+func Test_Template_FormatMap(t *testing.T) {
+	tmpl, err := Compile(format_today_info)
+	if err != nil {
+		t.Error("Test_Template_FormatMap throw error " + err.Error())
+		t.FailNow()
+	}
+	args := make(map[string]string)
+	args["DayofWeek"] = "Monday"
+	res, err := tmpl.FormatMap(&args)
+	if err != nil {
+		t.Error("Test_Template_FormatMap throw error " + err.Error())
+	}
+	if res != "Today is {NotMapTest1} Monday {NotMap_Test} {NotMap}" {
+		t.Error("Test_Template_FormatMap unexpected result " + res)
+	}
+}
+
+func Test_Template_FormatData(t *testing.T) {
+	tmpl, err := Compile(format_people)
+	if err != nil {
+		t.Error("Test_Template_FormatData throw error " + err.Error())
+		t.FailNow()
+	}
+	res, err := tmpl.FormatData(g_people)
+	if err != nil {
+		t.Error("Test_Template_FormatData throw error " + err.Error())
+	}
+	fmt.Println(res)
+
+	tmpl, err = Compile(format_time_map)
+	if err != nil {
+		t.Error("Test_Template_FormatData throw error " + err.Error())
+		t.FailNow()
+	}
+	time_map := make(map[string]string)
+	time_map["day"] = time.Date(2021, 8, 2, 10, 4, 5, 0, time.UTC).Format(time.RFC1123Z)
+	res, err = tmpl.FormatMap(&time_map)
+	if err != nil {
+		t.Error("Test_Template_FormatData throw error " + err.Error())
+	}
+	if res != "Current Time is 2021-08-02 10:04:05 Mon" {
+		t.Error("Test_Template_FormatData unexpected result " + res)
+	}
+}
+
+//------------------------------------------//
+//           Benchmark Test Below           //
+//------------------------------------------//
+
+func Benchmark_Template_Format(b *testing.B) {
+	tmpl, err := Compile(format_today)
+	if err != nil {
+		b.Error("Benchmark_Template_Format throw error " + err.Error())
+		return
+	}
+	for i := 0; i < b.N; i++ {
+		_, err = tmpl.Format("wonderful")
+		if err != nil {
+			b.Error("Benchmark_Template_Format throw error " + err.Error())
+			return
+		}
+	}
+}
+
+func Benchmark_Template_FormatData(b *testing.B) {
+	tmpl, err := Compile(format_people)
+	if err != nil {
+		b.Error("Benchmark_Template_FormatData throw error " + err.Error())
+		return
+	}
+	for i := 0; i < b.N; i++ {
+		_, err = tmpl.FormatData(g_people)
+		if err != nil {
+			b.Error("Benchmark_Template_FormatData throw error " + err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package strfmt
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+//unit_spec is a spec which divides a number by a power of its base and appends the unit of the power
+type unit_spec struct {
+	//base is 1000 or 1024
+	base int64
+	//units by power from min, sep is put between the number and the unit
+	units []string
+	min   int
+	sep   string
+	//decimals is the precision without digits after the spec name, whole renders power 0 without decimals, like bytes
+	decimals int
+	whole    bool
+}
+
+//unit_specs are the specs of format_unit by name, eng has no units and renders the exponent instead
+var unit_specs = map[string]*unit_spec{
+	"bytes":    {base: 1024, units: []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"}, sep: " ", decimals: 1, whole: true},
+	"bytes-si": {base: 1000, units: []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB"}, sep: " ", decimals: 1, whole: true},
+	"si":       {base: 1000, units: []string{"y", "z", "a", "f", "p", "n", "µ", "m", "", "k", "M", "G", "T", "P", "E", "Z", "Y"}, min: -8, decimals: 1},
+	"eng":      {base: 1000, decimals: 3},
+}
+
+//format_unit renders a number arg with a unit spec, the digits after the spec name are the decimals
+//	bytes 1.5 KiB, bytes-si 1.5 kB, si 1.5k or 1.5m, eng 1.500e3 with an exponent multiple of 3
+//	bytes, bytes-si and si have 1 decimal without the digits, eng has 3, bytes below 1 KiB or 1 kB are whole like 512 B
+//	a number which rounds to the next unit gets it, like 1.0 MiB instead of 1024.0 KiB, see WithRounding for the rounding
+//	string args are parsed as numbers, like the args of Format, ok is false if spec is not a unit spec
+func (o *options) format_unit(arg interface{}, spec string) (string, bool, error) {
+	spec, mode := o.split_rounding(spec)
+	name := spec_name(spec)
+	u, ok := unit_specs[name]
+	if !ok {
+		return "", false, nil
+	}
+	prec := u.decimals
+	if digits := spec[len(name):]; len(digits) > 0 {
+		if !is_number(digits) {
+			return "", true, fmt.Errorf("%q is not a unit spec, the spec name should be followed by decimals only", spec)
+		}
+		prec = parse_number(digits)
+		if max := o.limits.max_width(); prec < 0 || prec > max {
+			return "", true, fmt.Errorf("precision of %q exceeds MaxWidth %d", spec, max)
+		}
+	}
+
+	var d decimal
+	var err error
+	switch v := arg.(type) {
+	case string:
+		if d, err = parse_decimal(v); err != nil {
+			return "", true, fmt.Errorf("spec %q needs a number, not %q: %w", spec, v, err)
+		}
+	case []byte:
+		if d, err = parse_decimal(string(v)); err != nil {
+			return "", true, fmt.Errorf("spec %q needs a number, not %q: %w", spec, v, err)
+		}
+	default:
+		if d, ok, err = decimal_of(arg); !ok {
+			return "", true, errNoSpec
+		}
+		if err != nil {
+			if n, is_num := number_of(arg); is_num {
+				//Inf and NaN have no unit
+				return strconv.FormatFloat(n.f, 'g', -1, n.bits), true, nil
+			}
+			return "", true, err
+		}
+	}
+	return u.format(d, prec, mode), true, nil
+}
+
+//format renders d with the unit it is the closest to, prec is the decimals
+func (u *unit_spec) format(d decimal, prec int, mode RoundingMode) string {
+	decimals := func(power int) int {
+		if u.whole && power == 0 {
+			return 0
+		}
+		return prec
+	}
+
+	power := u.power_of(d)
+	r := u.scaled(d, power).round(decimals(power), mode)
+	if r.at_least(u.base) && (u.units == nil || power < u.min+len(u.units)-1) {
+		power++
+		r = u.scaled(d, power).round(decimals(power), mode)
+	}
+	if r.is_zero() {
+		power = 0
+	}
+
+	text := r.fixed(decimals(power))
+	if u.units == nil {
+		return text + "e" + strconv.Itoa(3*power)
+	}
+	return text + u.sep + u.units[power-u.min]
+}
+
+//power_of returns the power of base which the absolute d is at least, in the range of the units
+func (u *unit_spec) power_of(d decimal) int {
+	if d.is_zero() {
+		return 0
+	}
+	power := 0
+	if u.base == 1000 {
+		//d.exp-1 is the power of 10 of the first digit, floored to a multiple of 3
+		power = d.exp - 1
+		if power < 0 {
+			power -= 2
+		}
+		power /= 3
+	} else {
+		for power < u.min+len(u.units)-1 && u.scaled(d, power).at_least(u.base) {
+			power++
+		}
+	}
+	if u.units != nil {
+		if power < u.min {
+			power = u.min
+		}
+		if max := u.min + len(u.units) - 1; power > max {
+			power = max
+		}
+	}
+	return power
+}
+
+//scaled returns d divided by base to the power
+func (u *unit_spec) scaled(d decimal, power int) decimal {
+	if u.base == 1000 || power == 0 || d.is_zero() {
+		return d.shift(-3 * power)
+	}
+	//a decimal divided by 1024^power, which is 2^(10*power), has 10*power more decimals at most, so the text is exact
+	r, _ := new(big.Rat).SetString(d.fixed(len(d.fraction())))
+	divisor := new(big.Int).Exp(big.NewInt(u.base), big.NewInt(int64(power)), nil)
+	r.Quo(r, new(big.Rat).SetInt(divisor))
+	scaled, _ := parse_decimal(r.FloatString(len(d.fraction()) + 10*power))
+	return scaled
+}
+
+//at_least reports whether the absolute d is at least n
+func (d decimal) at_least(n int64) bool {
+	integer, text := d.integer(), strconv.FormatInt(n, 10)
+	return len(integer) > len(text) || (len(integer) == len(text) && strings.Compare(integer, text) >= 0)
+}
@@ -0,0 +1,107 @@
+package strfmt
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func Test_format_unit(t *testing.T) {
+	cases := []struct {
+		spec   string
+		arg    interface{}
+		result string
+	}{
+		{"bytes", 0, "0 B"},
+		{"bytes", 512, "512 B"},
+		{"bytes", 1023, "1023 B"},
+		{"bytes", 1536, "1.5 KiB"},
+		{"bytes", uint64(1572864), "1.5 MiB"},
+		{"bytes2", 1073741824, "1.00 GiB"},
+		{"bytes", 1048575, "1.0 MiB"},
+		{"bytes0", 1047552, "1023 KiB"},
+		{"bytes0", 1048064, "1 MiB"},
+		{"bytes", int64(math.MaxInt64), "8.0 EiB"},
+		{"bytes", -2048, "-2.0 KiB"},
+		{"bytes", 1536.75, "1.5 KiB"},
+		{"bytes3@truncate", 1999, "1.952 KiB"},
+		{"bytes", "1536", "1.5 KiB"},
+		{"bytes", new(big.Int).Lsh(big.NewInt(3), 90), "3072.0 YiB"},
+		{"bytes-si", 999, "999 B"},
+		{"bytes-si", 1500, "1.5 kB"},
+		{"bytes-si", 999950, "1.0 MB"},
+		{"bytes-si2", 2.5e9, "2.50 GB"},
+		{"si", 3200, "3.2k"},
+		{"si", 42, "42.0"},
+		{"si0", 1.5e6, "2M"},
+		{"si2", 0.00125, "1.25m"},
+		{"si", 0.0000042, "4.2µ"},
+		{"si", -1234567, "-1.2M"},
+		{"si", 0, "0.0"},
+		{"si", 1e30, "1000000.0Y"},
+		{"si", 1e-30, "0.0"},
+		{"si1@floor", 1999, "1.9k"},
+		{"si", json.Number("12345"), "12.3k"},
+		{"eng", 12345.678, "12.346e3"},
+		{"eng", 0.00012, "120.000e-6"},
+		{"eng2", 42, "42.00e0"},
+		{"eng1", 999.96, "1.0e3"},
+		{"eng0", -1e-10, "-100e-12"},
+		{"eng", 0, "0.000e0"},
+		{"eng", math.Inf(-1), "-Inf"},
+	}
+	o := new_options(nil)
+	for _, c := range cases {
+		res, kind, err := o.format_value(c.arg, c.spec)
+		if err != nil {
+			t.Errorf("Test_format_unit [%s] throw error %s, kind %s", c.spec, err.Error(), kind)
+			continue
+		}
+		if res != c.result {
+			t.Errorf("Test_format_unit [%s] %v unexpected result %s", c.spec, c.arg, res)
+		}
+	}
+
+	for _, arg := range []interface{}{"1.5 KiB", true, struct{}{}} {
+		if _, kind, err := o.format_value(arg, "bytes"); err == nil || kind != KindBadSpec {
+			t.Errorf("Test_format_unit [%v] should throw ErrBadSpec", arg)
+		}
+	}
+	if _, _, err := o.format_value(1, "si2x"); err == nil {
+		t.Error("Test_format_unit [si2x] should throw error")
+	}
+}
+
+func Test_Format_unit(t *testing.T) {
+	res, err := Format("{0:bytes} {1:bytes-si} {2:si}req/s {3:eng}", "1572864", "1500", "3200", "0.00012")
+	if err != nil || res != "1.5 MiB 1.5 kB 3.2kreq/s 120.000e-6" {
+		t.Error("Test_Format_unit unexpected result "+res, err)
+	}
+
+	type Disk struct {
+		Name string
+		Size uint64
+		Used float64
+	}
+	res, err = FormatData("{Name}: {Used:bytes}/{Size:bytes2}", Disk{Name: "sda", Size: 500107862016, Used: 1.5e11})
+	if err != nil || res != "sda: 139.7 GiB/465.76 GiB" {
+		t.Error("Test_Format_unit unexpected result "+res, err)
+	}
+
+	for _, arg := range []string{"big", "1e9000000000000000", "-1e-900000000", "NaN"} {
+		if _, err = Format("{0:si} {0:bytes} {0:eng}", arg); !errors.Is(err, ErrBadSpec) {
+			t.Errorf("Test_Format_unit [%s] should be ErrBadSpec %v", arg, err)
+		}
+	}
+	if _, err = FormatAny("{0:bytes}", json.Number("1e900000000")); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_Format_unit should be ErrBadSpec ", err)
+	}
+	if _, err = Bind[Disk]("{Name:bytes} {Size:si}"); err != nil {
+		t.Error("Test_Format_unit throw error " + err.Error())
+	}
+	if _, err = Bind[Disk]("{Used:bytes-x}"); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_Format_unit should be ErrBadSpec ", err)
+	}
+}
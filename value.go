@@ -0,0 +1,273 @@
+package strfmt
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+//errNoSpec is returned when a spec is given to an arg which does not support any spec
+var errNoSpec = errors.New("spec is not supported by this type")
+
+//WithNil sets the text of nil values, like a nil pointer field or a nil arg, "<nil>" by default
+func WithNil(text string) Option {
+	return func(o *options) {
+		o.nilText = text
+	}
+}
+
+//Formattable is implemented by types which render themselves, with the spec after ':' of a placeholder
+//	FormatStrfmt gets an empty spec for placeholders without one, an error it returns is ErrBadSpec
+//	the methods of an arg are looked up in order: Formattable, encoding.TextMarshaler, error, fmt.Stringer,
+//	times and builtin types come between Formattable and the others, values without any of them are rendered by their kinds
+type Formattable interface {
+	FormatStrfmt(spec string) (string, error)
+}
+
+//format_value converts a typed arg to text with the spec of the placeholder
+//	string args with a spec are parsed as numbers by numeric and unit specs like N2, #,##0.00 or bytes,
+//	or as times with the time layout of options by other specs
+//	the kind of the returned error is the ErrorKind to report, if err is not nil
+func (o *options) format_value(arg interface{}, spec string) (string, ErrorKind, error) {
+	return o.format_depth(arg, spec, 0)
+}
+
+//format_depth is format_value for a value nested depth levels in an arg, see sprint
+func (o *options) format_depth(arg interface{}, spec string, depth int) (string, ErrorKind, error) {
+	if len(spec) > 0 {
+		if fn, ok := o.specs[spec_name(spec)]; ok {
+			value, err := fn(arg, spec)
+			return value, KindBadSpec, err
+		}
+	}
+
+	if is_nil(arg) {
+		return o.nilText, 0, nil
+	}
+	if v, ok := arg.(Formattable); ok {
+		value, err := v.FormatStrfmt(spec)
+		return value, KindBadSpec, err
+	}
+	if len(spec) > 0 {
+		if value, ok, err := o.format_unit(arg, spec); ok {
+			return value, KindBadSpec, err
+		}
+	}
+
+	switch v := arg.(type) {
+	case string:
+		if len(spec) == 0 {
+			return v, 0, nil
+		}
+		if value, ok, err := o.format_text(v, spec); ok {
+			return value, KindBadSpec, err
+		}
+		t_arg, err := time.Parse(o.timeLayout, v)
+		if err != nil {
+			return "", KindBadTimeLayout, err
+		}
+		return o.format_time(t_arg, spec), 0, nil
+	case time.Time:
+		return o.format_time(v, spec), 0, nil
+	case *time.Time:
+		return o.format_time(*v, spec), 0, nil
+	case []byte:
+		if len(spec) == 0 {
+			return string(v), 0, nil
+		}
+		return o.format_value(string(v), spec)
+	}
+
+	if len(spec) > 0 {
+		if value, ok, err := o.format_number(arg, spec); ok {
+			return value, KindBadSpec, err
+		}
+		return "", KindBadSpec, errNoSpec
+	}
+
+	switch v := arg.(type) {
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return string(text), KindBadSpec, err
+	case error:
+		return v.Error(), 0, nil
+	case fmt.Stringer:
+		return v.String(), 0, nil
+	case bool:
+		return strconv.FormatBool(v), 0, nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), 0, nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), 0, nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), 0, nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), 0, nil
+	case int64:
+		return strconv.FormatInt(v, 10), 0, nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), 0, nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), 0, nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), 0, nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), 0, nil
+	case uint64:
+		return strconv.FormatUint(v, 10), 0, nil
+	case uintptr:
+		return strconv.FormatUint(uint64(v), 10), 0, nil
+	case float32:
+		return strconv.FormatFloat(float64(v), o.floatFmt, o.floatPrec, 32), 0, nil
+	case float64:
+		return strconv.FormatFloat(v, o.floatFmt, o.floatPrec, 64), 0, nil
+	case complex64:
+		return strconv.FormatComplex(complex128(v), o.floatFmt, o.floatPrec, 64), 0, nil
+	case complex128:
+		return strconv.FormatComplex(v, o.floatFmt, o.floatPrec, 128), 0, nil
+	}
+	return o.sprint(reflect.ValueOf(arg), depth), 0, nil
+}
+
+//arg_of returns the value in v to format, or a pointer to it if only the pointer has the methods format_value looks for,
+//	like a field of type T whose String method has the receiver *T
+//	a value which is not addressable, like a field of a struct passed by value, gets a pointer to a copy like method paths do
+func arg_of(v reflect.Value) interface{} {
+	if !v.IsValid() || v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || has_format_method(v.Type()) {
+		return interface_of(v)
+	}
+	if !has_format_method(reflect.PtrTo(v.Type())) {
+		return interface_of(v)
+	}
+	if !v.CanAddr() {
+		if !v.CanInterface() {
+			//a field which is not exported could not be copied
+			return interface_of(v)
+		}
+		return pointer_to(v).Interface()
+	}
+	if v.CanInterface() {
+		return v.Addr().Interface()
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Interface()
+}
+
+//has_format_method reports whether values of typ have any method format_value looks for
+func has_format_method(typ reflect.Type) bool {
+	return typ.Implements(formattable_type) || typ.Implements(text_marshaler_type) || typ.Implements(error_type) || typ.Implements(stringer_type)
+}
+
+var (
+	formattable_type    = reflect.TypeOf((*Formattable)(nil)).Elem()
+	text_marshaler_type = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+//is_nil reports whether arg is nil or a nil pointer
+func is_nil(arg interface{}) bool {
+	if arg == nil {
+		return true
+	}
+	v := reflect.ValueOf(arg)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+//sprint renders the values format_value does not know by their kinds, like named numbers, structs, maps and slices
+//	elements are rendered by format_value again, so a time.Time or a fmt.Stringer in a slice works as well
+//	values nested deeper than MaxDepth of Limits are "...", which stops cycles like a map holding itself
+func (o *options) sprint(v reflect.Value, depth int) string {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return o.nilText
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), o.floatFmt, o.floatPrec, v.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), o.floatFmt, o.floatPrec, v.Type().Bits())
+	case reflect.String:
+		return v.String()
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			return o.nilText
+		}
+		return "0x" + strconv.FormatUint(uint64(v.Pointer()), 16)
+	}
+
+	if depth >= o.limits.max_depth() {
+		return "..."
+	}
+	elem := func(e reflect.Value) string {
+		value, _, _ := o.format_depth(arg_of(e), "", depth+1)
+		return value
+	}
+
+	var b strings.Builder
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return o.nilText
+		}
+		return elem(v.Elem())
+	case reflect.Struct:
+		b.WriteByte('{')
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" && !o.unexported {
+				continue
+			}
+			if n > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(elem(v.Field(i)))
+			n++
+		}
+		b.WriteByte('}')
+	case reflect.Map:
+		//keys are sorted by their text like fmt does, so the result is stable
+		pairs := make([][2]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			pairs = append(pairs, [2]string{elem(iter.Key()), elem(iter.Value())})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		b.WriteString("map[")
+		for i, pair := range pairs {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(pair[0] + ":" + pair[1])
+		}
+		b.WriteByte(']')
+	case reflect.Slice, reflect.Array:
+		b.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(elem(v.Index(i)))
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+//format_time renders t in the location of options with spec as time layout, or the time layout of options without spec
+func (o *options) format_time(t time.Time, spec string) string {
+	if o.location != nil {
+		t = t.In(o.location)
+	}
+	if len(spec) == 0 {
+		return t.Format(o.timeLayout)
+	}
+	return t.Format(spec)
+}
@@ -0,0 +1,178 @@
+package strfmt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+func Test_FormatAny(t *testing.T) {
+	day := time.Date(2021, 8, 2, 10, 4, 5, 0, time.UTC)
+	res, err := FormatAny("{0}|{1,4}|{2}|{3}|{4:2006-01-02}|{5}|{6}|{7}|{8}|{9}",
+		"str", 42, uint8(7), true, day, 90*time.Second, net.IPv4(10, 0, 0, 1), errors.New("bad"), []byte("bytes"), nil)
+	if err != nil {
+		t.Error("Test_FormatAny throw error " + err.Error())
+	}
+	if res != "str|  42|7|true|2021-08-02|1m30s|10.0.0.1|bad|bytes|<nil>" {
+		t.Error("Test_FormatAny unexpected result " + res)
+	}
+
+	res, err = New(WithFloatFormat('f', -1)).FormatAny("{0} {1}", 1.25, float32(0.1))
+	if err != nil || res != "1.25 0.1" {
+		t.Error("Test_FormatAny unexpected result "+res, err)
+	}
+
+	res, err = FormatAny(format_time_short, day)
+	if err != nil || res != "Current Time is 10:04AM" {
+		t.Error("Test_FormatAny unexpected result "+res, err)
+	}
+	fmt.Println(res)
+
+	_, err = FormatAny("{0:2006}", 42)
+	if !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatAny should be ErrBadSpec ", err)
+	}
+}
+
+type Level int16
+
+type Chain struct {
+	Name string
+	Next *Chain
+}
+
+func Test_FormatAny_kinds(t *testing.T) {
+	var due *time.Time
+	res, err := New(WithFloatFormat('f', -1)).FormatAny("{0}|{1}|{2}|{3}|{4}|{5}|{6:2006}",
+		Level(3), uint16(9), uintptr(10), complex(1.5, -2), []float32{0.5}, map[string]int{"b": 2, "a": 1}, due)
+	if err != nil {
+		t.Error("Test_FormatAny_kinds throw error " + err.Error())
+	}
+	if res != "3|9|10|(1.5-2i)|[0.5]|map[a:1 b:2]|<nil>" {
+		t.Error("Test_FormatAny_kinds unexpected result " + res)
+	}
+
+	res, err = New(WithNil("-")).FormatAny("{0} {1}", nil, (*Chain)(nil))
+	if err != nil || res != "- -" {
+		t.Error("Test_FormatAny_kinds [WithNil] unexpected result "+res, err)
+	}
+
+	//cycles are cut at MaxDepth, which counts pointers as well
+	node := &Chain{Name: "a"}
+	node.Next = node
+	res, err = New(WithLimits(Limits{MaxDepth: 6})).FormatAny("{0}", node)
+	if err != nil || res != "{a {a {a ...}}}" {
+		t.Error("Test_FormatAny_kinds [cycle] unexpected result "+res, err)
+	}
+	self := map[string]interface{}{"name": "m"}
+	self["self"] = self
+	res, err = New(WithLimits(Limits{MaxDepth: 2})).FormatAny("{0}", self)
+	if err != nil || res != "map[name:m self:map[name:m self:...]]" {
+		t.Error("Test_FormatAny_kinds [cycle] unexpected result "+res, err)
+	}
+}
+
+type Color int
+
+func (c Color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+type UUID [4]byte
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%x-%x", u[:2], u[2:])), nil
+}
+
+func (u UUID) String() string {
+	return "not used"
+}
+
+type Money struct {
+	Cents int64
+}
+
+func (m *Money) FormatStrfmt(spec string) (string, error) {
+	switch spec {
+	case "":
+		return fmt.Sprintf("%d.%02d", m.Cents/100, m.Cents%100), nil
+	case "cents":
+		return fmt.Sprint(m.Cents), nil
+	}
+	return "", errors.New("unknown money spec " + spec)
+}
+
+type Version struct {
+	Major, Minor int
+}
+
+func (v *Version) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+func Test_FormatAny_methods(t *testing.T) {
+	res, err := FormatAny("{0} {1} {2} {3:cents} {4}", Color(1), UUID{1, 2, 3, 4}, &Money{Cents: 1999}, &Money{Cents: 5}, net.IP{10, 0, 0, 1})
+	if err != nil || res != "green 0102-0304 19.99 5 10.0.0.1" {
+		t.Error("Test_FormatAny_methods unexpected result "+res, err)
+	}
+	if _, err = FormatAny("{0:euro}", &Money{}); !errors.Is(err, ErrBadSpec) {
+		t.Error("Test_FormatAny_methods should be ErrBadSpec ", err)
+	}
+
+	//methods of pointer receivers are used for addressable fields
+	data := &struct {
+		Price   Money
+		Version Version
+		Colors  []Color
+	}{Money{Cents: 250}, Version{1, 2}, []Color{0, 1}}
+	res, err = FormatData("{Price} {Price:cents} {Version} {Colors}", data)
+	if err != nil || res != "2.50 250 v1.2 [red green]" {
+		t.Error("Test_FormatAny_methods unexpected result "+res, err)
+	}
+
+	//and for fields of a struct passed by value, through a copy, whatever WithUnexported is
+	type Release struct {
+		Version Version
+		Price   Money
+		Tags    map[string]Version
+	}
+	release := Release{Version{2, 0}, Money{Cents: 5}, map[string]Version{"lts": {1, 9}}}
+	for _, f := range []*Formatter{New(), New(WithUnexported(true))} {
+		res, err = f.FormatData("{Version} {Price} {Tags.lts} {Tags}", release)
+		if err != nil || res != "v2.0 0.05 v1.9 map[lts:v1.9]" {
+			t.Error("Test_FormatAny_methods [by value] unexpected result "+res, err)
+		}
+	}
+}
+
+func Test_FormatAny_float(t *testing.T) {
+	type Bill struct {
+		Total float64
+		Rate  float32
+	}
+	bill := &Bill{Total: 1234.5, Rate: 0.1}
+	res, err := FormatData("{Total} {Rate} {Total:R}", bill)
+	if err != nil || res != "1234.5 0.1 1234.5" {
+		t.Error("Test_FormatAny_float unexpected result "+res, err)
+	}
+	a, b := 0.1, 0.2
+	res, err = FormatAny("{0} {1} {2}", a+b, 1e21, float32(16777216.0))
+	if err != nil || res != "0.30000000000000004 1e+21 1.6777216e+07" {
+		t.Error("Test_FormatAny_float unexpected result "+res, err)
+	}
+
+	f := New(WithFloatFormat('f', 2))
+	res, err = f.FormatData("{Total} {Rate} {Total:F0} {Total:R}", bill)
+	if err != nil || res != "1234.50 0.10 1235 1234.5" {
+		t.Error("Test_FormatAny_float [fixed] unexpected result "+res, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Test_FormatAny_float WithFloatFormat should panic")
+		}
+	}()
+	WithFloatFormat('z', 2)
+}
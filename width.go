@@ -0,0 +1,215 @@
+package strfmt
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+//WidthMode is the way to measure an arg when padding it to the width of a placeholder
+type WidthMode int
+
+const (
+	//WidthDisplay counts terminal columns: wide and fullwidth runes are 2,
+	//combining marks and zero width joiners are 0, a grapheme cluster like an emoji sequence is measured as a whole
+	WidthDisplay WidthMode = iota
+	//WidthRunes counts runes
+	WidthRunes
+	//WidthBytes counts bytes, which is how widths were measured before
+	WidthBytes
+)
+
+//WithWidthMode sets how args are measured for the width of placeholders, WidthDisplay by default
+func WithWidthMode(mode WidthMode) Option {
+	return func(o *options) {
+		o.widthMode = mode
+	}
+}
+
+//measure returns the width of s in mode
+func (mode WidthMode) measure(s string) int {
+	switch mode {
+	case WidthRunes:
+		return utf8.RuneCountInString(s)
+	case WidthBytes:
+		return len(s)
+	}
+	return DisplayWidth(s)
+}
+
+//WithEllipsis sets the text to end truncated values of placeholders like {0,.20!}, "…" by default
+func WithEllipsis(ellipsis string) Option {
+	return func(o *options) {
+		o.ellipsis = ellipsis
+	}
+}
+
+//cluster_width returns the width of a grapheme cluster in mode, display is its display width
+func (mode WidthMode) cluster_width(cluster string, display int) int {
+	switch mode {
+	case WidthRunes:
+		return utf8.RuneCountInString(cluster)
+	case WidthBytes:
+		return len(cluster)
+	}
+	return display
+}
+
+//truncate cuts s to max width at grapheme cluster boundaries, ellipsis is put at the end if s is cut
+//	ellipsis is dropped if it is wider than max
+func (mode WidthMode) truncate(s string, max int, ellipsis string) string {
+	if mode.measure(s) <= max {
+		return s
+	}
+	limit := max - mode.measure(ellipsis)
+	if limit < 0 {
+		limit, ellipsis = max, ""
+	}
+
+	width, end := 0, 0
+	for end < len(s) {
+		size, display := next_cluster(s[end:])
+		w := mode.cluster_width(s[end:end+size], display)
+		if width+w > limit {
+			break
+		}
+		width += w
+		end += size
+	}
+	return s[:end] + ellipsis
+}
+
+//DisplayWidth returns the number of terminal columns s takes
+//	east asian wide and fullwidth runes take 2 columns, combining marks, joiners and controls take 0,
+//	each grapheme cluster takes the width of its base rune, or 2 for emoji presentation and flags
+func DisplayWidth(s string) int {
+	width := 0
+	for len(s) > 0 {
+		size, w := next_cluster(s)
+		width += w
+		s = s[size:]
+	}
+	return width
+}
+
+const (
+	zero_width_joiner = 0x200D
+	variation_emoji   = 0xFE0F
+)
+
+//next_cluster returns the byte size and display width of the grapheme cluster at the start of s
+//	it is a simplified segmentation: a base rune with following marks, variation selectors,
+//	emoji modifiers and tags, runes joined by ZWJ, a pair of regional indicators, or CR LF
+func next_cluster(s string) (int, int) {
+	base, size := utf8.DecodeRuneInString(s)
+	width := rune_width(base)
+
+	if base == '\r' && size < len(s) && s[size] == '\n' {
+		return size + 1, 0
+	}
+	if is_regional_indicator(base) {
+		if r, n := utf8.DecodeRuneInString(s[size:]); is_regional_indicator(r) {
+			return size + n, 2
+		}
+		return size, width
+	}
+
+	for size < len(s) {
+		r, n := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case r == zero_width_joiner:
+			//the joined rune belongs to the cluster
+			size += n
+			if size < len(s) {
+				_, n = utf8.DecodeRuneInString(s[size:])
+				size += n
+			}
+			continue
+		case r == variation_emoji:
+			//emoji presentation of a narrow symbol like ❤️
+			if width == 1 {
+				width = 2
+			}
+		case is_extend(r):
+		default:
+			return size, width
+		}
+		size += n
+	}
+	return size, width
+}
+
+//is_extend reports whether r extends the grapheme cluster before it
+func is_extend(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.Is(unicode.Mc, r) ||
+		(r >= 0xFE00 && r <= 0xFE0F) || //variation selectors
+		(r >= 0x1F3FB && r <= 0x1F3FF) || //emoji modifiers
+		(r >= 0xE0020 && r <= 0xE007F) || //tags
+		(r >= 0xE0100 && r <= 0xE01EF) || //variation selectors supplement
+		(r >= 0x1160 && r <= 0x11FF) //hangul jungseong and jongseong
+}
+
+func is_regional_indicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+//rune_width returns the number of columns r takes out of a cluster
+func rune_width(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x1100:
+		if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+			return 0
+		}
+		return 1
+	case is_extend(r) || unicode.Is(unicode.Cf, r):
+		return 0
+	case in_table(r, wide_table):
+		return 2
+	}
+	return 1
+}
+
+//in_table reports whether r is in one of the sorted ranges of table
+func in_table(r rune, table [][2]rune) bool {
+	lo, hi := 0, len(table)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < table[mid][0]:
+			hi = mid
+		case r > table[mid][1]:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+//wide_table is the east asian wide (W) and fullwidth (F) ranges of unicode, emoji presentation included
+var wide_table = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x303E},
+	{0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE6F}, {0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x17000, 0x18AFF}, {0x1B000, 0x1B2FF}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202}, {0x1F210, 0x1F23B},
+	{0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320},
+	{0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E},
+	{0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E},
+	{0x1F550, 0x1F567}, {0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4},
+	{0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2},
+	{0x1F6D5, 0x1F6D7}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FAFF},
+	{0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
@@ -0,0 +1,67 @@
+package strfmt
+
+import (
+	"testing"
+)
+
+func Test_DisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"day":        3,
+		"日本":         4,
+		"café":       4,
+		"cafe\u0301": 4,
+		"한국어":        6,
+		"😀":          2,
+		"❤️":         2,
+		"👍🏽":         2,
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467": 2,
+		"🇯🇵🇨🇳":                                       4,
+		"ｆｕｌｌ":                                       8,
+		"a\tb":                                       2,
+	}
+	for str, width := range cases {
+		if w := DisplayWidth(str); w != width {
+			t.Errorf("Test_DisplayWidth [%s] expect %d, got %d", str, width, w)
+		}
+	}
+}
+
+func Test_WithWidthMode(t *testing.T) {
+	res, err := Format("[{0,6}][{1,-6}][{2,6}]", "日本", "café", "👨‍👩‍👧")
+	if err != nil {
+		t.Error("Test_WithWidthMode throw error " + err.Error())
+	}
+	if res != "[  日本][café  ][    👨‍👩‍👧]" {
+		t.Error("Test_WithWidthMode unexpected result " + res)
+	}
+
+	res, _ = New(WithWidthMode(WidthRunes)).Format("[{0,6}][{1,-6}]", "日本", "café")
+	if res != "[    日本][café  ]" {
+		t.Error("Test_WithWidthMode [WidthRunes] unexpected result " + res)
+	}
+
+	res, _ = New(WithWidthMode(WidthBytes)).Format("[{0,8}][{1,-6}]", "日本", "café")
+	if res != "[  日本][café ]" {
+		t.Error("Test_WithWidthMode [WidthBytes] unexpected result " + res)
+	}
+}
+
+func Test_truncate(t *testing.T) {
+	res, err := Format("[{0,-8.8}][{0,.6!}][{1,.5!}][{2,6.3}][{3,.3!}][{4,.3}]", "Alexander Hamilton", "日本語テキスト", "ab", "👨‍👩‍👧👍", "cafés")
+	if err != nil {
+		t.Error("Test_truncate throw error " + err.Error())
+	}
+	if res != "[Alexande][Alexa…][日本…][    ab][👨‍👩‍👧…][caf]" {
+		t.Error("Test_truncate unexpected result " + res)
+	}
+
+	res, _ = New(WithEllipsis("...")).Format("[{0,.10!}][{0,.2!}]", "Alexander Hamilton")
+	if res != "[Alexand...][Al]" {
+		t.Error("Test_truncate [WithEllipsis] unexpected result " + res)
+	}
+
+	res, _ = New(WithWidthMode(WidthRunes)).Format("[{0,.3!}]", "日本語テキスト")
+	if res != "[日本…]" {
+		t.Error("Test_truncate [WidthRunes] unexpected result " + res)
+	}
+}